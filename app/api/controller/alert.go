@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"hyperfocus/app/api"
+	"hyperfocus/app/api/mapper"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/rofleksey/meg"
+	"github.com/samber/oops"
+)
+
+func (s *Server) ListAlerts(ctx context.Context, _ api.ListAlertsRequestObject) (api.ListAlertsResponseObject, error) {
+	data, err := s.alertService.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, oops.Errorf("alertService.ListSubscriptions: %w", err)
+	}
+
+	return api.ListAlerts200JSONResponse{
+		Data: pie.Map(data, mapper.MapAlert),
+	}, nil
+}
+
+func (s *Server) CreateAlert(ctx context.Context, request api.CreateAlertRequestObject) (api.CreateAlertResponseObject, error) {
+	data, err := s.alertService.CreateSubscription(ctx, mapper.MapAlertRequest(request.Body))
+	if err != nil {
+		return nil, oops.Errorf("alertService.CreateSubscription: %w", err)
+	}
+
+	return api.CreateAlert200JSONResponse(mapper.MapAlert(*data)), nil
+}
+
+func (s *Server) UpdateAlert(ctx context.Context, request api.UpdateAlertRequestObject) (api.UpdateAlertResponseObject, error) {
+	data, err := s.alertService.UpdateSubscription(ctx, request.Id, mapper.MapAlertRequest(request.Body))
+	if err != nil {
+		return nil, oops.Errorf("alertService.UpdateSubscription: %w", err)
+	}
+
+	return api.UpdateAlert200JSONResponse(mapper.MapAlert(*data)), nil
+}
+
+func (s *Server) DeleteAlert(ctx context.Context, request api.DeleteAlertRequestObject) (api.DeleteAlertResponseObject, error) {
+	if err := s.alertService.DeleteSubscription(ctx, request.Id); err != nil {
+		return nil, oops.Errorf("alertService.DeleteSubscription: %w", err)
+	}
+
+	return api.DeleteAlert204Response{}, nil
+}
+
+func (s *Server) ListAlertDeliveries(ctx context.Context, request api.ListAlertDeliveriesRequestObject) (api.ListAlertDeliveriesResponseObject, error) {
+	limit := meg.GetPtrOrDefault(request.Params.Limit, 20)
+
+	data, err := s.alertService.ListDeliveries(ctx, request.Id, int32(limit)) //nolint:gosec
+	if err != nil {
+		return nil, oops.Errorf("alertService.ListDeliveries: %w", err)
+	}
+
+	return api.ListAlertDeliveries200JSONResponse{
+		Data: pie.Map(data, mapper.MapAlertDelivery),
+	}, nil
+}
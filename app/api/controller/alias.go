@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"hyperfocus/app/api"
+	"hyperfocus/app/api/mapper"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/samber/oops"
+)
+
+func (s *Server) ListStreamerAliases(ctx context.Context, request api.ListStreamerAliasesRequestObject) (api.ListStreamerAliasesResponseObject, error) {
+	data, err := s.aliasService.List(ctx, request.Login)
+	if err != nil {
+		return nil, oops.Errorf("aliasService.List: %w", err)
+	}
+
+	return api.ListStreamerAliases200JSONResponse{
+		Data: pie.Map(data, mapper.MapStreamerAlias),
+	}, nil
+}
+
+func (s *Server) CreateStreamerAlias(ctx context.Context, request api.CreateStreamerAliasRequestObject) (api.CreateStreamerAliasResponseObject, error) {
+	data, err := s.aliasService.Create(ctx, request.Login, request.Body.Nickname)
+	if err != nil {
+		return nil, oops.Errorf("aliasService.Create: %w", err)
+	}
+
+	return api.CreateStreamerAlias200JSONResponse(mapper.MapStreamerAlias(*data)), nil
+}
+
+func (s *Server) DeleteStreamerAlias(ctx context.Context, request api.DeleteStreamerAliasRequestObject) (api.DeleteStreamerAliasResponseObject, error) {
+	if err := s.aliasService.Delete(ctx, request.Login, request.Id); err != nil {
+		return nil, oops.Errorf("aliasService.Delete: %w", err)
+	}
+
+	return api.DeleteStreamerAlias204Response{}, nil
+}
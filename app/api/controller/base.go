@@ -5,8 +5,11 @@ import (
 	"hyperfocus/app/api"
 	"hyperfocus/app/config"
 	"hyperfocus/app/database"
+	"hyperfocus/app/service/alert"
+	"hyperfocus/app/service/alias"
 	"hyperfocus/app/service/limits"
 	"hyperfocus/app/service/search"
+	"hyperfocus/app/service/stream"
 
 	"github.com/samber/do"
 )
@@ -20,6 +23,9 @@ type Server struct {
 	queries       database.TxQueries
 	limitsService *limits.Service
 	searchService *search.Service
+	alertService  *alert.Service
+	aliasService  *alias.Service
+	streamService *stream.Service
 }
 
 func NewStrictServer(di *do.Injector) *Server {
@@ -30,5 +36,8 @@ func NewStrictServer(di *do.Injector) *Server {
 		queries:       do.MustInvoke[database.TxQueries](di),
 		limitsService: do.MustInvoke[*limits.Service](di),
 		searchService: do.MustInvoke[*search.Service](di),
+		alertService:  do.MustInvoke[*alert.Service](di),
+		aliasService:  do.MustInvoke[*alias.Service](di),
+		streamService: do.MustInvoke[*stream.Service](di),
 	}
 }
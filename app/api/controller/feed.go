@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"encoding/json"
+	"hyperfocus/app/api"
+	"hyperfocus/app/api/mapper"
+	"hyperfocus/app/config"
+	"hyperfocus/app/service/auth"
+	"hyperfocus/app/service/feed"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+// claimsLocal is the fiber local with the claims of the authenticated feed client
+const claimsLocal = "feed_claims"
+
+// writeWait is how long a single message may take to write, slower clients are disconnected
+const writeWait = 10 * time.Second
+
+// maxCommandSize limits the size of the client messages
+const maxCommandSize = 4096
+
+// FeedController serves the live feed over websocket, it is registered outside the OpenAPI handlers
+type FeedController struct {
+	cfg         *config.Config
+	authService *auth.Service
+	feedService *feed.Service
+}
+
+func NewFeedController(di *do.Injector) *FeedController {
+	return &FeedController{
+		cfg:         do.MustInvoke[*config.Config](di),
+		authService: do.MustInvoke[*auth.Service](di),
+		feedService: do.MustInvoke[*feed.Service](di),
+	}
+}
+
+// Authenticate checks the optional access token before the upgrade, browsers can't set headers
+// on websocket requests so the token may also be passed as the access_token query parameter
+func (c *FeedController) Authenticate(ctx *fiber.Ctx) error {
+	tokenStr := ctx.Query("access_token")
+	if tokenStr == "" {
+		tokenStr, _ = strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+	}
+
+	if tokenStr == "" {
+		return ctx.Next()
+	}
+
+	claims, err := c.authService.ParseToken(tokenStr)
+	if err != nil {
+		return oops.
+			With("status_code", http.StatusUnauthorized).
+			Public("invalid access token").
+			Wrapf(err, "ParseToken")
+	}
+
+	ctx.Locals(claimsLocal, claims)
+
+	return ctx.Next()
+}
+
+// Serve delivers the events of the subscribed topics until the client disconnects or stops answering pings
+func (c *FeedController) Serve(conn *websocket.Conn) {
+	claims, _ := conn.Locals(claimsLocal).(*auth.Claims)
+	shutdown, _ := conn.Locals("done").(<-chan struct{})
+
+	subscriber := c.feedService.NewSubscriber(claims)
+	defer subscriber.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	replies := make(chan api.FeedEvent, 1)
+	readerDone := make(chan struct{})
+
+	if topics := conn.Query("topics"); topics != "" {
+		replies <- applyFeedCommand(subscriber, api.FeedCommand{
+			Action: api.FeedCommandActionSubscribe,
+			Topics: strings.Split(topics, ","),
+		})
+	}
+
+	pingInterval := time.Duration(c.cfg.Feed.PingInterval) * time.Second
+
+	go func() {
+		defer close(readerDone)
+		readFeedCommands(conn, subscriber, replies, stop, 2*pingInterval)
+	}()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		var message api.FeedEvent
+
+		select {
+		case <-shutdown:
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down"), time.Now().Add(writeWait))
+			return
+		case <-readerDone:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			continue
+		case message = <-replies:
+		case event := <-subscriber.Events():
+			if dropped := int(subscriber.TakeDropped()); dropped > 0 {
+				if err := writeFeedEvent(conn, api.FeedEvent{Type: api.FeedEventTypeDropped, Dropped: &dropped}); err != nil {
+					return
+				}
+			}
+
+			message = mapper.MapFeedEvent(event)
+		}
+
+		if err := writeFeedEvent(conn, message); err != nil {
+			slog.Debug("Failed to write feed event",
+				slog.String("ip", conn.IP()),
+				slog.Any("error", err),
+			)
+			return
+		}
+	}
+}
+
+// readFeedCommands applies the client commands until the connection fails,
+// the read deadline is extended by every pong, so silent clients time out
+func readFeedCommands(
+	conn *websocket.Conn,
+	subscriber *feed.Subscriber,
+	replies chan<- api.FeedEvent,
+	stop <-chan struct{},
+	pongWait time.Duration,
+) {
+	conn.SetReadLimit(maxCommandSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				slog.Debug("Feed client disconnected",
+					slog.String("ip", conn.IP()),
+					slog.Any("error", err),
+				)
+			}
+			return
+		}
+
+		var reply api.FeedEvent
+
+		var command api.FeedCommand
+		if err = json.Unmarshal(data, &command); err != nil {
+			reply = feedError("invalid command")
+		} else {
+			reply = applyFeedCommand(subscriber, command)
+		}
+
+		select {
+		case replies <- reply:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// applyFeedCommand changes the subscriptions, the reply has the resulting topics and the first error if any
+func applyFeedCommand(subscriber *feed.Subscriber, command api.FeedCommand) api.FeedEvent {
+	switch command.Action {
+	case api.FeedCommandActionSubscribe:
+		for _, topic := range command.Topics {
+			if err := subscriber.Subscribe(topic); err != nil {
+				// the preceding topics of the command stay subscribed
+				reply := feedError(oops.GetPublic(err, "failed to subscribe"))
+				topics := subscriber.Topics()
+				reply.Topics = &topics
+
+				return reply
+			}
+		}
+	case api.FeedCommandActionUnsubscribe:
+		for _, topic := range command.Topics {
+			subscriber.Unsubscribe(topic)
+		}
+	default:
+		return feedError("unknown action")
+	}
+
+	topics := subscriber.Topics()
+
+	return api.FeedEvent{
+		Type:   api.FeedEventTypeSubscribed,
+		Topics: &topics,
+	}
+}
+
+func feedError(message string) api.FeedEvent {
+	return api.FeedEvent{
+		Type:    api.FeedEventTypeError,
+		Message: &message,
+	}
+}
+
+func writeFeedEvent(conn *websocket.Conn, event api.FeedEvent) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return conn.WriteJSON(event) //nolint:wrapcheck
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"hyperfocus/app/api"
 	"hyperfocus/app/api/mapper"
+	"hyperfocus/app/service/search"
 
 	"github.com/elliotchance/pie/v2"
 	"github.com/samber/oops"
@@ -16,6 +17,30 @@ func (s *Server) SearchPlayers(ctx context.Context, request api.SearchPlayersReq
 	}
 
 	return api.SearchPlayers200JSONResponse{
-		Data: pie.Map(data, mapper.MapStream),
+		Data: pie.Map(data, mapper.MapSearchResult),
+	}, nil
+}
+
+func (s *Server) SearchPlayerHistory(ctx context.Context, request api.SearchPlayerHistoryRequestObject) (api.SearchPlayerHistoryResponseObject, error) {
+	data, err := s.searchService.SearchHistory(ctx, request.Body.Query, request.Body.Since, request.Body.Until)
+	if err != nil {
+		return nil, oops.Errorf("searchService.SearchHistory: %w", err)
+	}
+
+	return api.SearchPlayerHistory200JSONResponse{
+		Data: pie.Map(data, mapper.MapStreamSightings),
+	}, nil
+}
+
+func (s *Server) SearchStreamer(ctx context.Context, request api.SearchStreamerRequestObject) (api.SearchStreamerResponseObject, error) {
+	data, err := s.searchService.SearchStreamer(ctx, request.Body.Login)
+	if err != nil {
+		return nil, oops.Errorf("searchService.SearchStreamer: %w", err)
+	}
+
+	return api.SearchStreamer200JSONResponse{
+		Data: pie.Map(data, func(m search.Match) api.Stream {
+			return mapper.MapStream(m.Stream)
+		}),
 	}, nil
 }
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"hyperfocus/app/api"
+	"hyperfocus/app/api/mapper"
+	"hyperfocus/app/service/stream"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/rofleksey/meg"
+	"github.com/samber/oops"
+)
+
+var streamSorts = map[api.ListStreamsParamsSort]string{
+	api.ListStreamsParamsSortName:         stream.SortName,
+	api.ListStreamsParamsSortViewers:      stream.SortViewers,
+	api.ListStreamsParamsSortLastAnalyzed: stream.SortLastAnalyzed,
+}
+
+func (s *Server) ListStreams(ctx context.Context, request api.ListStreamsRequestObject) (api.ListStreamsResponseObject, error) {
+	params := request.Params
+
+	page, err := s.streamService.List(ctx, stream.ListParams{
+		Cursor:         meg.GetPtrOrZero(params.Cursor),
+		Limit:          meg.GetPtrOrDefault(params.Limit, 20),
+		Sort:           streamSorts[meg.GetPtrOrDefault(params.Sort, api.ListStreamsParamsSortName)],
+		Online:         params.Online,
+		HasPlayers:     params.HasPlayers,
+		AnalyzedAfter:  params.AnalyzedAfter,
+		AnalyzedBefore: params.AnalyzedBefore,
+		Language:       params.Language,
+		MinViewers:     params.MinViewers,
+		MaxViewers:     params.MaxViewers,
+	})
+	if err != nil {
+		return nil, oops.Errorf("streamService.List: %w", err)
+	}
+
+	response := api.ListStreams200JSONResponse{
+		Data: pie.Map(page.Streams, mapper.MapStreamInfo),
+	}
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
+	}
+
+	return response, nil
+}
+
+func (s *Server) GetStream(ctx context.Context, request api.GetStreamRequestObject) (api.GetStreamResponseObject, error) {
+	details, err := s.streamService.Get(ctx, request.Id, meg.GetPtrOrDefault(request.Params.Limit, 20))
+	if err != nil {
+		return nil, oops.Errorf("streamService.Get: %w", err)
+	}
+
+	return api.GetStream200JSONResponse(mapper.MapStreamDetails(details)), nil
+}
@@ -12,11 +12,261 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	PermissionsScopes = "Permissions.Scopes"
+)
+
+// Defines values for AlertChannelType.
+const (
+	AlertChannelTypeDiscord  AlertChannelType = "discord"
+	AlertChannelTypeTelegram AlertChannelType = "telegram"
+	AlertChannelTypeTwitch   AlertChannelType = "twitch"
+	AlertChannelTypeWebhook  AlertChannelType = "webhook"
+)
+
+// Defines values for FeedAnalysisPhase.
+const (
+	FeedAnalysisPhaseLobby      FeedAnalysisPhase = "lobby"
+	FeedAnalysisPhaseMenu       FeedAnalysisPhase = "menu"
+	FeedAnalysisPhaseScoreboard FeedAnalysisPhase = "scoreboard"
+	FeedAnalysisPhaseTrial      FeedAnalysisPhase = "trial"
+	FeedAnalysisPhaseUnknown    FeedAnalysisPhase = "unknown"
+)
+
+// Defines values for FeedCommandAction.
+const (
+	FeedCommandActionSubscribe   FeedCommandAction = "subscribe"
+	FeedCommandActionUnsubscribe FeedCommandAction = "unsubscribe"
+)
+
+// Defines values for FeedEventType.
+const (
+	FeedEventTypeAlert      FeedEventType = "alert"
+	FeedEventTypeAnalysis   FeedEventType = "analysis"
+	FeedEventTypeDropped    FeedEventType = "dropped"
+	FeedEventTypeError      FeedEventType = "error"
+	FeedEventTypeSubscribed FeedEventType = "subscribed"
+)
+
+// Defines values for FeedMatchMatchKind.
+const (
+	FeedMatchMatchKindDistance   FeedMatchMatchKind = "distance"
+	FeedMatchMatchKindExact      FeedMatchMatchKind = "exact"
+	FeedMatchMatchKindNormalized FeedMatchMatchKind = "normalized"
+	FeedMatchMatchKindSubstring  FeedMatchMatchKind = "substring"
+)
+
+// Defines values for SearchResultMatchKind.
+const (
+	SearchResultMatchKindDistance   SearchResultMatchKind = "distance"
+	SearchResultMatchKindExact      SearchResultMatchKind = "exact"
+	SearchResultMatchKindNormalized SearchResultMatchKind = "normalized"
+	SearchResultMatchKindSubstring  SearchResultMatchKind = "substring"
+)
+
+// Defines values for StreamAnalysisPhase.
+const (
+	StreamAnalysisPhaseLobby      StreamAnalysisPhase = "lobby"
+	StreamAnalysisPhaseMenu       StreamAnalysisPhase = "menu"
+	StreamAnalysisPhaseScoreboard StreamAnalysisPhase = "scoreboard"
+	StreamAnalysisPhaseTrial      StreamAnalysisPhase = "trial"
+	StreamAnalysisPhaseUnknown    StreamAnalysisPhase = "unknown"
+)
+
+// Defines values for StreamAnalysisSkipped.
+const (
+	StreamAnalysisSkippedAds     StreamAnalysisSkipped = "ads"
+	StreamAnalysisSkippedError   StreamAnalysisSkipped = "error"
+	StreamAnalysisSkippedOffline StreamAnalysisSkipped = "offline"
+)
+
+// Defines values for StreamInfoPhase.
+const (
+	StreamInfoPhaseLobby      StreamInfoPhase = "lobby"
+	StreamInfoPhaseMenu       StreamInfoPhase = "menu"
+	StreamInfoPhaseScoreboard StreamInfoPhase = "scoreboard"
+	StreamInfoPhaseTrial      StreamInfoPhase = "trial"
+	StreamInfoPhaseUnknown    StreamInfoPhase = "unknown"
+)
+
+// Defines values for StreamPlayerRole.
+const (
+	StreamPlayerRoleKiller   StreamPlayerRole = "killer"
+	StreamPlayerRoleSurvivor StreamPlayerRole = "survivor"
+)
+
+// Defines values for StreamerAliasSource.
+const (
+	StreamerAliasSourceAuto   StreamerAliasSource = "auto"
+	StreamerAliasSourceManual StreamerAliasSource = "manual"
+)
+
+// Defines values for ListStreamsParamsSort.
+const (
+	ListStreamsParamsSortLastAnalyzed ListStreamsParamsSort = "lastAnalyzed"
+	ListStreamsParamsSortName         ListStreamsParamsSort = "name"
+	ListStreamsParamsSortViewers      ListStreamsParamsSort = "viewers"
+)
+
+// Alert defines model for Alert.
+type Alert struct {
+	Channels      []AlertChannel `json:"channels"`
+	ConfirmHits   int32          `json:"confirmHits"`
+	ConfirmWindow int32          `json:"confirmWindow"`
+	Created       time.Time      `json:"created"`
+	Enabled       bool           `json:"enabled"`
+	Id            int64          `json:"id"`
+	Queries       []string       `json:"queries"`
+	RequireMutual bool           `json:"requireMutual"`
+	Streamer      string         `json:"streamer"`
+	Updated       time.Time      `json:"updated"`
+}
+
+// AlertChannel defines model for AlertChannel.
+type AlertChannel struct {
+	Id     int64            `json:"id"`
+	Target string           `json:"target"`
+	Type   AlertChannelType `json:"type"`
+}
+
+// AlertChannelRequest defines model for AlertChannelRequest.
+type AlertChannelRequest struct {
+	// Secret Generic webhook signing secret
+	Secret *string `json:"secret,omitempty"`
+
+	// Target Twitch channel, telegram chat id, discord or generic webhook url
+	Target *string          `json:"target,omitempty"`
+	Type   AlertChannelType `json:"type"`
+}
+
+// AlertChannelType defines model for AlertChannelType.
+type AlertChannelType string
+
+// AlertDelivery defines model for AlertDelivery.
+type AlertDelivery struct {
+	ChannelType    AlertChannelType `json:"channelType"`
+	Created        time.Time        `json:"created"`
+	Error          *string          `json:"error,omitempty"`
+	Success        bool             `json:"success"`
+	TargetStreamer string           `json:"targetStreamer"`
+}
+
+// AlertDeliveryListResponse defines model for AlertDeliveryListResponse.
+type AlertDeliveryListResponse struct {
+	Data []AlertDelivery `json:"data"`
+}
+
+// AlertListResponse defines model for AlertListResponse.
+type AlertListResponse struct {
+	Data []Alert `json:"data"`
+}
+
+// AlertRequest defines model for AlertRequest.
+type AlertRequest struct {
+	// Channels Notification channels, defaults to twitch chat of the streamer
+	Channels *[]AlertChannelRequest `json:"channels,omitempty"`
+
+	// ConfirmHits Number of analyze cycles the streamer must be detected in before the alert fires
+	ConfirmHits *int32 `json:"confirmHits,omitempty"`
+
+	// ConfirmWindow Number of the latest analyze cycles confirmHits are counted in, defaults to confirmHits
+	ConfirmWindow *int32   `json:"confirmWindow,omitempty"`
+	Enabled       *bool    `json:"enabled,omitempty"`
+	Queries       []string `json:"queries"`
+
+	// RequireMutual Only alert when the target stream also shows the streamer in its lobby
+	RequireMutual *bool  `json:"requireMutual,omitempty"`
+	Streamer      string `json:"streamer"`
+}
+
+// BoundingBox Position of the nickname on the 1080p frame in pixels
+type BoundingBox struct {
+	Height int `json:"height"`
+	Width  int `json:"width"`
+	X      int `json:"x"`
+	Y      int `json:"y"`
+}
+
+// FeedAlert defines model for FeedAlert.
+type FeedAlert struct {
+	AlertStreamer   string    `json:"alertStreamer"`
+	MatchScore      *float64  `json:"matchScore,omitempty"`
+	MatchedNickname *string   `json:"matchedNickname,omitempty"`
+	Message         string    `json:"message"`
+	MutualScore     float64   `json:"mutualScore"`
+	TargetStreamer  string    `json:"targetStreamer"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// FeedAnalysis Lobby recognized on a freshly analyzed frame
+type FeedAnalysis struct {
+	AnalyzedAt time.Time         `json:"analyzedAt"`
+	Name       string            `json:"name"`
+	Nicknames  []string          `json:"nicknames"`
+	Phase      FeedAnalysisPhase `json:"phase"`
+	Players    []StreamPlayer    `json:"players"`
+}
+
+// FeedAnalysisPhase defines model for FeedAnalysis.Phase.
+type FeedAnalysisPhase string
+
+// FeedCommand Message sent by the client over the /v1/ws live feed
+type FeedCommand struct {
+	Action FeedCommandAction `json:"action"`
+
+	// Topics stream:<login>, nickname:<query> or alerts, alerts needs a token with the read:alerts permission
+	Topics []string `json:"topics"`
+}
+
+// FeedCommandAction defines model for FeedCommand.Action.
+type FeedCommandAction string
+
+// FeedEvent Message sent by the server over the /v1/ws live feed
+type FeedEvent struct {
+	Alert *FeedAlert `json:"alert,omitempty"`
+
+	// Analysis Lobby recognized on a freshly analyzed frame
+	Analysis *FeedAnalysis `json:"analysis,omitempty"`
+
+	// Dropped Number of the events dropped since the previous dropped event
+	Dropped *int `json:"dropped,omitempty"`
+
+	// Match Best nickname of the lobby matching the query of a nickname topic
+	Match *FeedMatch `json:"match,omitempty"`
+
+	// Message Error message
+	Message *string `json:"message,omitempty"`
+
+	// Topic Topic the analysis or alert was delivered for
+	Topic *string `json:"topic,omitempty"`
+
+	// Topics Current subscriptions of the client, sent with subscribed and with the subscription errors
+	Topics *[]string `json:"topics,omitempty"`
+
+	// Type subscribed acknowledges a command, dropped tells that the client reads the events too slowly
+	Type FeedEventType `json:"type"`
+}
+
+// FeedEventType subscribed acknowledges a command, dropped tells that the client reads the events too slowly
+type FeedEventType string
+
+// FeedMatch Best nickname of the lobby matching the query of a nickname topic
+type FeedMatch struct {
+	MatchKind       FeedMatchMatchKind `json:"matchKind"`
+	MatchedNickname string             `json:"matchedNickname"`
+	Score           float64            `json:"score"`
+}
+
+// FeedMatchMatchKind defines model for FeedMatch.MatchKind.
+type FeedMatchMatchKind string
+
 // General defines model for General.
 type General struct {
 	Error      bool   `json:"error"`
@@ -30,6 +280,28 @@ type HealthResponse struct {
 	Version   string `json:"version"`
 }
 
+// SearchHistoryRequest defines model for SearchHistoryRequest.
+type SearchHistoryRequest struct {
+	Query string `json:"query"`
+
+	// Since Start of the time window, defaults to 24 hours ago
+	Since *time.Time `json:"since,omitempty"`
+
+	// Until End of the time window, defaults to now
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// SearchHistoryResponse defines model for SearchHistoryResponse.
+type SearchHistoryResponse struct {
+	Data []StreamSightings `json:"data"`
+}
+
+// SearchPlayersResponse defines model for SearchPlayersResponse.
+type SearchPlayersResponse struct {
+	// Data Matching streams, the best matches first
+	Data []SearchResult `json:"data"`
+}
+
 // SearchRequest defines model for SearchRequest.
 type SearchRequest struct {
 	Query string `json:"query"`
@@ -40,221 +312,1685 @@ type SearchResponse struct {
 	Data []Stream `json:"data"`
 }
 
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	// MatchKind exact - same nickname, normalized - same after unifying case, spaces and OCR confusables, distance - close by the edit distance, substring - contains the query
+	MatchKind SearchResultMatchKind `json:"matchKind"`
+
+	// MatchedNickname Nickname of the lobby that matched the query best
+	MatchedNickname string   `json:"matchedNickname"`
+	Name            string   `json:"name"`
+	Nicknames       []string `json:"nicknames"`
+
+	// Players Nicknames recognized on the latest frame with their OCR confidence and position
+	Players []StreamPlayer `json:"players"`
+
+	// Score Match score in (0, 1], 1 means the nickname is exactly the query
+	Score float64 `json:"score"`
+}
+
+// SearchResultMatchKind exact - same nickname, normalized - same after unifying case, spaces and OCR confusables, distance - close by the edit distance, substring - contains the query
+type SearchResultMatchKind string
+
+// SearchStreamerRequest defines model for SearchStreamerRequest.
+type SearchStreamerRequest struct {
+	Login string `json:"login"`
+}
+
 // Stream defines model for Stream.
 type Stream struct {
 	Name      string   `json:"name"`
 	Nicknames []string `json:"nicknames"`
+
+	// Players Nicknames recognized on the latest frame with their OCR confidence and position
+	Players []StreamPlayer `json:"players"`
 }
 
-// SearchPlayersJSONRequestBody defines body for SearchPlayers for application/json ContentType.
-type SearchPlayersJSONRequestBody = SearchRequest
+// StreamAnalysis defines model for StreamAnalysis.
+type StreamAnalysis struct {
+	AnalyzedAt time.Time `json:"analyzedAt"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Health check
-	// (GET /healthz)
-	HealthCheck(c *fiber.Ctx) error
-	// Search players
-	// (POST /search)
-	SearchPlayers(c *fiber.Ctx) error
-}
+	// ErrorCategory Stage that failed if skipped is error
+	ErrorCategory *string             `json:"errorCategory,omitempty"`
+	Phase         StreamAnalysisPhase `json:"phase"`
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	// Players Nicknames recognized on the frame with their OCR confidence and position
+	Players []StreamPlayer `json:"players"`
+
+	// Skipped Why the frame was not analyzed, absent if it was
+	Skipped *StreamAnalysisSkipped `json:"skipped,omitempty"`
 }
 
-type MiddlewareFunc fiber.Handler
+// StreamAnalysisPhase defines model for StreamAnalysis.Phase.
+type StreamAnalysisPhase string
 
-// HealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) HealthCheck(c *fiber.Ctx) error {
+// StreamAnalysisSkipped Why the frame was not analyzed, absent if it was
+type StreamAnalysisSkipped string
 
-	return siw.Handler.HealthCheck(c)
+// StreamDetails defines model for StreamDetails.
+type StreamDetails struct {
+	// LastError Latest failed fetch or analysis of the stream
+	LastError *StreamError `json:"lastError,omitempty"`
+	Stream    StreamInfo   `json:"stream"`
+
+	// Timeline Latest analyses from the newest to the oldest
+	Timeline []StreamAnalysis `json:"timeline"`
 }
 
-// SearchPlayers operation middleware
-func (siw *ServerInterfaceWrapper) SearchPlayers(c *fiber.Ctx) error {
+// StreamError Latest failed fetch or analysis of the stream
+type StreamError struct {
+	At time.Time `json:"at"`
 
-	return siw.Handler.SearchPlayers(c)
+	// Category Stage that failed: timeout, playlist, quality, frame, ocr, database or unknown
+	Category string `json:"category"`
 }
 
-// FiberServerOptions provides options for the Fiber server.
-type FiberServerOptions struct {
-	BaseURL     string
-	Middlewares []MiddlewareFunc
+// StreamInfo defines model for StreamInfo.
+type StreamInfo struct {
+	Language string `json:"language"`
+
+	// LastAnalyzed Time of the latest analysis, absent if the stream was never analyzed
+	LastAnalyzed *time.Time `json:"lastAnalyzed,omitempty"`
+	Name         string     `json:"name"`
+	Nicknames    []string   `json:"nicknames"`
+	Online       bool       `json:"online"`
+
+	// Phase Game phase on the latest frame
+	Phase StreamInfoPhase `json:"phase"`
+
+	// Players Nicknames recognized on the latest frame with their OCR confidence and position
+	Players     []StreamPlayer `json:"players"`
+	ViewerCount int            `json:"viewerCount"`
 }
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router fiber.Router, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+// StreamInfoPhase Game phase on the latest frame
+type StreamInfoPhase string
+
+// StreamListResponse defines model for StreamListResponse.
+type StreamListResponse struct {
+	Data []StreamInfo `json:"data"`
+
+	// NextCursor Cursor of the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
 }
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
+// StreamPlayer defines model for StreamPlayer.
+type StreamPlayer struct {
+	// Box Position of the nickname on the 1080p frame in pixels
+	Box        *BoundingBox `json:"box,omitempty"`
+	Confidence float64      `json:"confidence"`
+	Nickname   string       `json:"nickname"`
 
-	for _, m := range options.Middlewares {
-		router.Use(fiber.Handler(m))
-	}
+	// Role Side of the player, only known on the end-game scoreboard
+	Role *StreamPlayerRole `json:"role,omitempty"`
 
-	router.Get(options.BaseURL+"/healthz", wrapper.HealthCheck)
+	// Slot Index of the HUD or scoreboard row, top to bottom
+	Slot int `json:"slot"`
+}
 
-	router.Post(options.BaseURL+"/search", wrapper.SearchPlayers)
+// StreamPlayerRole Side of the player, only known on the end-game scoreboard
+type StreamPlayerRole string
 
+// StreamSightings defines model for StreamSightings.
+type StreamSightings struct {
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Name      string    `json:"name"`
+	Nicknames []string  `json:"nicknames"`
 }
 
-type HealthCheckRequestObject struct {
+// StreamerAlias defines model for StreamerAlias.
+type StreamerAlias struct {
+	// Confidence Manual aliases have confidence 1, auto ones grow with every sighting of the first in-trial HUD row on the own stream
+	Confidence float64             `json:"confidence"`
+	Created    time.Time           `json:"created"`
+	Id         int64               `json:"id"`
+	LastSeen   *time.Time          `json:"lastSeen,omitempty"`
+	Nickname   string              `json:"nickname"`
+	Sightings  int32               `json:"sightings"`
+	Source     StreamerAliasSource `json:"source"`
+	Streamer   string              `json:"streamer"`
+	Updated    time.Time           `json:"updated"`
 }
 
-type HealthCheckResponseObject interface {
-	VisitHealthCheckResponse(ctx *fiber.Ctx) error
+// StreamerAliasListResponse defines model for StreamerAliasListResponse.
+type StreamerAliasListResponse struct {
+	Data []StreamerAlias `json:"data"`
 }
 
-type HealthCheck200JSONResponse HealthResponse
+// StreamerAliasRequest defines model for StreamerAliasRequest.
+type StreamerAliasRequest struct {
+	Nickname string `json:"nickname"`
+}
 
-func (response HealthCheck200JSONResponse) VisitHealthCheckResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+// StreamerAliasSource defines model for StreamerAliasSource.
+type StreamerAliasSource string
 
-	return ctx.JSON(&response)
+// ListAlertDeliveriesParams defines parameters for ListAlertDeliveries.
+type ListAlertDeliveriesParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
-type HealthCheckdefaultJSONResponse struct {
-	Body       General
-	StatusCode int
+// ListStreamsParams defines parameters for ListStreams.
+type ListStreamsParams struct {
+	// Cursor nextCursor of the previous page, it is only valid with the same sort
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Sort name - alphabetically, viewers - most watched first, lastAnalyzed - most recently analyzed first
+	Sort   *ListStreamsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Online *bool                  `form:"online,omitempty" json:"online,omitempty"`
+
+	// HasPlayers Whether any nicknames were recognized on the latest frame
+	HasPlayers     *bool      `form:"hasPlayers,omitempty" json:"hasPlayers,omitempty"`
+	AnalyzedAfter  *time.Time `form:"analyzedAfter,omitempty" json:"analyzedAfter,omitempty"`
+	AnalyzedBefore *time.Time `form:"analyzedBefore,omitempty" json:"analyzedBefore,omitempty"`
+
+	// Language Stream language as reported by twitch, e.g. en
+	Language   *string `form:"language,omitempty" json:"language,omitempty"`
+	MinViewers *int    `form:"minViewers,omitempty" json:"minViewers,omitempty"`
+	MaxViewers *int    `form:"maxViewers,omitempty" json:"maxViewers,omitempty"`
 }
 
-func (response HealthCheckdefaultJSONResponse) VisitHealthCheckResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(response.StatusCode)
+// ListStreamsParamsSort defines parameters for ListStreams.
+type ListStreamsParamsSort string
 
-	return ctx.JSON(&response.Body)
+// GetStreamParams defines parameters for GetStream.
+type GetStreamParams struct {
+	// Limit Number of the latest analyses in the timeline, the server keeps only the latest processing.timeline_size (50 by default) analyses per stream
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
-type SearchPlayersRequestObject struct {
-	Body *SearchPlayersJSONRequestBody
-}
+// CreateAlertJSONRequestBody defines body for CreateAlert for application/json ContentType.
+type CreateAlertJSONRequestBody = AlertRequest
 
-type SearchPlayersResponseObject interface {
-	VisitSearchPlayersResponse(ctx *fiber.Ctx) error
-}
+// UpdateAlertJSONRequestBody defines body for UpdateAlert for application/json ContentType.
+type UpdateAlertJSONRequestBody = AlertRequest
 
-type SearchPlayers200JSONResponse SearchResponse
+// SearchPlayersJSONRequestBody defines body for SearchPlayers for application/json ContentType.
+type SearchPlayersJSONRequestBody = SearchRequest
 
-func (response SearchPlayers200JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+// SearchPlayerHistoryJSONRequestBody defines body for SearchPlayerHistory for application/json ContentType.
+type SearchPlayerHistoryJSONRequestBody = SearchHistoryRequest
 
-	return ctx.JSON(&response)
-}
+// SearchStreamerJSONRequestBody defines body for SearchStreamer for application/json ContentType.
+type SearchStreamerJSONRequestBody = SearchStreamerRequest
 
-type SearchPlayers400JSONResponse General
+// CreateStreamerAliasJSONRequestBody defines body for CreateStreamerAlias for application/json ContentType.
+type CreateStreamerAliasJSONRequestBody = StreamerAliasRequest
 
-func (response SearchPlayers400JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(400)
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List alert subscriptions
+	// (GET /alerts)
+	ListAlerts(c *fiber.Ctx) error
+	// Create alert subscription
+	// (POST /alerts)
+	CreateAlert(c *fiber.Ctx) error
+	// Delete alert subscription
+	// (DELETE /alerts/{id})
+	DeleteAlert(c *fiber.Ctx, id int64) error
+	// Update alert subscription
+	// (PUT /alerts/{id})
+	UpdateAlert(c *fiber.Ctx, id int64) error
+	// List recent alert deliveries
+	// (GET /alerts/{id}/deliveries)
+	ListAlertDeliveries(c *fiber.Ctx, id int64, params ListAlertDeliveriesParams) error
+	// Health check
+	// (GET /healthz)
+	HealthCheck(c *fiber.Ctx) error
+	// Search players
+	// (POST /search)
+	SearchPlayers(c *fiber.Ctx) error
+	// Search player sightings history
+	// (POST /search/history)
+	SearchPlayerHistory(c *fiber.Ctx) error
+	// Find streams that have the streamer in their lobby
+	// (POST /search/streamer)
+	SearchStreamer(c *fiber.Ctx) error
+	// List known in-game names of the streamer
+	// (GET /streamers/{login}/aliases)
+	ListStreamerAliases(c *fiber.Ctx, login string) error
+	// Add in-game name of the streamer
+	// (POST /streamers/{login}/aliases)
+	CreateStreamerAlias(c *fiber.Ctx, login string) error
+	// Delete in-game name of the streamer
+	// (DELETE /streamers/{login}/aliases/{id})
+	DeleteStreamerAlias(c *fiber.Ctx, login string, id int64) error
+	// List tracked streams
+	// (GET /streams)
+	ListStreams(c *fiber.Ctx, params ListStreamsParams) error
+	// Get stream state and its latest analyses
+	// (GET /streams/{id})
+	GetStream(c *fiber.Ctx, id string, params GetStreamParams) error
+}
 
-	return ctx.JSON(&response)
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
 }
 
-type SearchPlayers401JSONResponse General
+type MiddlewareFunc fiber.Handler
 
-func (response SearchPlayers401JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(401)
+// ListAlerts operation middleware
+func (siw *ServerInterfaceWrapper) ListAlerts(c *fiber.Ctx) error {
 
-	return ctx.JSON(&response)
+	c.Context().SetUserValue(PermissionsScopes, []string{"read:alerts"})
+
+	return siw.Handler.ListAlerts(c)
 }
 
-type SearchPlayers403JSONResponse General
+// CreateAlert operation middleware
+func (siw *ServerInterfaceWrapper) CreateAlert(c *fiber.Ctx) error {
 
-func (response SearchPlayers403JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(403)
+	c.Context().SetUserValue(PermissionsScopes, []string{"write:alerts"})
 
-	return ctx.JSON(&response)
+	return siw.Handler.CreateAlert(c)
 }
 
-type SearchPlayers404JSONResponse General
+// DeleteAlert operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAlert(c *fiber.Ctx) error {
 
-func (response SearchPlayers404JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(404)
+	var err error
 
-	return ctx.JSON(&response)
-}
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-type SearchPlayers500JSONResponse General
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-func (response SearchPlayers500JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	c.Context().SetUserValue(PermissionsScopes, []string{"write:alerts"})
 
-	return ctx.JSON(&response)
+	return siw.Handler.DeleteAlert(c, id)
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Health check
-	// (GET /healthz)
-	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
-	// Search players
-	// (POST /search)
-	SearchPlayers(ctx context.Context, request SearchPlayersRequestObject) (SearchPlayersResponseObject, error)
-}
+// UpdateAlert operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAlert(c *fiber.Ctx) error {
 
-type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+	var err error
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	c.Context().SetUserValue(PermissionsScopes, []string{"write:alerts"})
+
+	return siw.Handler.UpdateAlert(c, id)
 }
 
-// HealthCheck operation middleware
-func (sh *strictHandler) HealthCheck(ctx *fiber.Ctx) error {
-	var request HealthCheckRequestObject
+// ListAlertDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListAlertDeliveries(c *fiber.Ctx) error {
 
-	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.HealthCheck(ctx.UserContext(), request.(HealthCheckRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "HealthCheck")
-	}
+	var err error
 
-	response, err := handler(ctx, request)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return err
-	} else if validResponse, ok := response.(HealthCheckResponseObject); ok {
-		if err := validResponse.VisitHealthCheckResponse(ctx); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
-		}
-	} else if response != nil {
-		return fmt.Errorf("unexpected response type: %T", response)
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
-	return nil
-}
 
-// SearchPlayers operation middleware
-func (sh *strictHandler) SearchPlayers(ctx *fiber.Ctx) error {
-	var request SearchPlayersRequestObject
+	c.Context().SetUserValue(PermissionsScopes, []string{"read:alerts"})
 
-	var body SearchPlayersJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAlertDeliveriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
-	request.Body = &body
 
-	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.SearchPlayers(ctx.UserContext(), request.(SearchPlayersRequestObject))
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.ListAlertDeliveries(c, id, params)
+}
+
+// HealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) HealthCheck(c *fiber.Ctx) error {
+
+	return siw.Handler.HealthCheck(c)
+}
+
+// SearchPlayers operation middleware
+func (siw *ServerInterfaceWrapper) SearchPlayers(c *fiber.Ctx) error {
+
+	return siw.Handler.SearchPlayers(c)
+}
+
+// SearchPlayerHistory operation middleware
+func (siw *ServerInterfaceWrapper) SearchPlayerHistory(c *fiber.Ctx) error {
+
+	return siw.Handler.SearchPlayerHistory(c)
+}
+
+// SearchStreamer operation middleware
+func (siw *ServerInterfaceWrapper) SearchStreamer(c *fiber.Ctx) error {
+
+	return siw.Handler.SearchStreamer(c)
+}
+
+// ListStreamerAliases operation middleware
+func (siw *ServerInterfaceWrapper) ListStreamerAliases(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "login" -------------
+	var login string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "login", c.Params("login"), &login, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter login: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(PermissionsScopes, []string{"read:aliases"})
+
+	return siw.Handler.ListStreamerAliases(c, login)
+}
+
+// CreateStreamerAlias operation middleware
+func (siw *ServerInterfaceWrapper) CreateStreamerAlias(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "login" -------------
+	var login string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "login", c.Params("login"), &login, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter login: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(PermissionsScopes, []string{"write:aliases"})
+
+	return siw.Handler.CreateStreamerAlias(c, login)
+}
+
+// DeleteStreamerAlias operation middleware
+func (siw *ServerInterfaceWrapper) DeleteStreamerAlias(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "login" -------------
+	var login string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "login", c.Params("login"), &login, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter login: %w", err).Error())
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(PermissionsScopes, []string{"write:aliases"})
+
+	return siw.Handler.DeleteStreamerAlias(c, login, id)
+}
+
+// ListStreams operation middleware
+func (siw *ServerInterfaceWrapper) ListStreams(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListStreamsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter cursor: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", query, &params.Sort)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "online" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "online", query, &params.Online)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter online: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "hasPlayers" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hasPlayers", query, &params.HasPlayers)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter hasPlayers: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "analyzedAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "analyzedAfter", query, &params.AnalyzedAfter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter analyzedAfter: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "analyzedBefore" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "analyzedBefore", query, &params.AnalyzedBefore)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter analyzedBefore: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "language" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "language", query, &params.Language)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter language: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "minViewers" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minViewers", query, &params.MinViewers)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter minViewers: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "maxViewers" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxViewers", query, &params.MaxViewers)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter maxViewers: %w", err).Error())
+	}
+
+	return siw.Handler.ListStreams(c, params)
+}
+
+// GetStream operation middleware
+func (siw *ServerInterfaceWrapper) GetStream(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStreamParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetStream(c, id, params)
+}
+
+// FiberServerOptions provides options for the Fiber server.
+type FiberServerOptions struct {
+	BaseURL     string
+	Middlewares []MiddlewareFunc
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router fiber.Router, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	for _, m := range options.Middlewares {
+		router.Use(fiber.Handler(m))
+	}
+
+	router.Get(options.BaseURL+"/alerts", wrapper.ListAlerts)
+
+	router.Post(options.BaseURL+"/alerts", wrapper.CreateAlert)
+
+	router.Delete(options.BaseURL+"/alerts/:id", wrapper.DeleteAlert)
+
+	router.Put(options.BaseURL+"/alerts/:id", wrapper.UpdateAlert)
+
+	router.Get(options.BaseURL+"/alerts/:id/deliveries", wrapper.ListAlertDeliveries)
+
+	router.Get(options.BaseURL+"/healthz", wrapper.HealthCheck)
+
+	router.Post(options.BaseURL+"/search", wrapper.SearchPlayers)
+
+	router.Post(options.BaseURL+"/search/history", wrapper.SearchPlayerHistory)
+
+	router.Post(options.BaseURL+"/search/streamer", wrapper.SearchStreamer)
+
+	router.Get(options.BaseURL+"/streamers/:login/aliases", wrapper.ListStreamerAliases)
+
+	router.Post(options.BaseURL+"/streamers/:login/aliases", wrapper.CreateStreamerAlias)
+
+	router.Delete(options.BaseURL+"/streamers/:login/aliases/:id", wrapper.DeleteStreamerAlias)
+
+	router.Get(options.BaseURL+"/streams", wrapper.ListStreams)
+
+	router.Get(options.BaseURL+"/streams/:id", wrapper.GetStream)
+
+}
+
+type ListAlertsRequestObject struct {
+}
+
+type ListAlertsResponseObject interface {
+	VisitListAlertsResponse(ctx *fiber.Ctx) error
+}
+
+type ListAlerts200JSONResponse AlertListResponse
+
+func (response ListAlerts200JSONResponse) VisitListAlertsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlerts400JSONResponse General
+
+func (response ListAlerts400JSONResponse) VisitListAlertsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlerts401JSONResponse General
+
+func (response ListAlerts401JSONResponse) VisitListAlertsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlerts403JSONResponse General
+
+func (response ListAlerts403JSONResponse) VisitListAlertsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlerts404JSONResponse General
+
+func (response ListAlerts404JSONResponse) VisitListAlertsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlerts500JSONResponse General
+
+func (response ListAlerts500JSONResponse) VisitListAlertsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type CreateAlertRequestObject struct {
+	Body *CreateAlertJSONRequestBody
+}
+
+type CreateAlertResponseObject interface {
+	VisitCreateAlertResponse(ctx *fiber.Ctx) error
+}
+
+type CreateAlert200JSONResponse Alert
+
+func (response CreateAlert200JSONResponse) VisitCreateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateAlert400JSONResponse General
+
+func (response CreateAlert400JSONResponse) VisitCreateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type CreateAlert401JSONResponse General
+
+func (response CreateAlert401JSONResponse) VisitCreateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type CreateAlert403JSONResponse General
+
+func (response CreateAlert403JSONResponse) VisitCreateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type CreateAlert404JSONResponse General
+
+func (response CreateAlert404JSONResponse) VisitCreateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type CreateAlert500JSONResponse General
+
+func (response CreateAlert500JSONResponse) VisitCreateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteAlertRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteAlertResponseObject interface {
+	VisitDeleteAlertResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteAlert204Response struct {
+}
+
+func (response DeleteAlert204Response) VisitDeleteAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteAlert400JSONResponse General
+
+func (response DeleteAlert400JSONResponse) VisitDeleteAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteAlert401JSONResponse General
+
+func (response DeleteAlert401JSONResponse) VisitDeleteAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteAlert403JSONResponse General
+
+func (response DeleteAlert403JSONResponse) VisitDeleteAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteAlert404JSONResponse General
+
+func (response DeleteAlert404JSONResponse) VisitDeleteAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteAlert500JSONResponse General
+
+func (response DeleteAlert500JSONResponse) VisitDeleteAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAlertRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *UpdateAlertJSONRequestBody
+}
+
+type UpdateAlertResponseObject interface {
+	VisitUpdateAlertResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateAlert200JSONResponse Alert
+
+func (response UpdateAlert200JSONResponse) VisitUpdateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAlert400JSONResponse General
+
+func (response UpdateAlert400JSONResponse) VisitUpdateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAlert401JSONResponse General
+
+func (response UpdateAlert401JSONResponse) VisitUpdateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAlert403JSONResponse General
+
+func (response UpdateAlert403JSONResponse) VisitUpdateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAlert404JSONResponse General
+
+func (response UpdateAlert404JSONResponse) VisitUpdateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateAlert500JSONResponse General
+
+func (response UpdateAlert500JSONResponse) VisitUpdateAlertResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveriesRequestObject struct {
+	Id     int64 `json:"id"`
+	Params ListAlertDeliveriesParams
+}
+
+type ListAlertDeliveriesResponseObject interface {
+	VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error
+}
+
+type ListAlertDeliveries200JSONResponse AlertDeliveryListResponse
+
+func (response ListAlertDeliveries200JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries400JSONResponse General
+
+func (response ListAlertDeliveries400JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries401JSONResponse General
+
+func (response ListAlertDeliveries401JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries403JSONResponse General
+
+func (response ListAlertDeliveries403JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries404JSONResponse General
+
+func (response ListAlertDeliveries404JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ListAlertDeliveries500JSONResponse General
+
+func (response ListAlertDeliveries500JSONResponse) VisitListAlertDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type HealthCheckRequestObject struct {
+}
+
+type HealthCheckResponseObject interface {
+	VisitHealthCheckResponse(ctx *fiber.Ctx) error
+}
+
+type HealthCheck200JSONResponse HealthResponse
+
+func (response HealthCheck200JSONResponse) VisitHealthCheckResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type HealthCheckdefaultJSONResponse struct {
+	Body       General
+	StatusCode int
+}
+
+func (response HealthCheckdefaultJSONResponse) VisitHealthCheckResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(response.StatusCode)
+
+	return ctx.JSON(&response.Body)
+}
+
+type SearchPlayersRequestObject struct {
+	Body *SearchPlayersJSONRequestBody
+}
+
+type SearchPlayersResponseObject interface {
+	VisitSearchPlayersResponse(ctx *fiber.Ctx) error
+}
+
+type SearchPlayers200JSONResponse SearchPlayersResponse
+
+func (response SearchPlayers200JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayers400JSONResponse General
+
+func (response SearchPlayers400JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayers401JSONResponse General
+
+func (response SearchPlayers401JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayers403JSONResponse General
+
+func (response SearchPlayers403JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayers404JSONResponse General
+
+func (response SearchPlayers404JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayers500JSONResponse General
+
+func (response SearchPlayers500JSONResponse) VisitSearchPlayersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayerHistoryRequestObject struct {
+	Body *SearchPlayerHistoryJSONRequestBody
+}
+
+type SearchPlayerHistoryResponseObject interface {
+	VisitSearchPlayerHistoryResponse(ctx *fiber.Ctx) error
+}
+
+type SearchPlayerHistory200JSONResponse SearchHistoryResponse
+
+func (response SearchPlayerHistory200JSONResponse) VisitSearchPlayerHistoryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayerHistory400JSONResponse General
+
+func (response SearchPlayerHistory400JSONResponse) VisitSearchPlayerHistoryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayerHistory401JSONResponse General
+
+func (response SearchPlayerHistory401JSONResponse) VisitSearchPlayerHistoryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayerHistory403JSONResponse General
+
+func (response SearchPlayerHistory403JSONResponse) VisitSearchPlayerHistoryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayerHistory404JSONResponse General
+
+func (response SearchPlayerHistory404JSONResponse) VisitSearchPlayerHistoryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type SearchPlayerHistory500JSONResponse General
+
+func (response SearchPlayerHistory500JSONResponse) VisitSearchPlayerHistoryResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type SearchStreamerRequestObject struct {
+	Body *SearchStreamerJSONRequestBody
+}
+
+type SearchStreamerResponseObject interface {
+	VisitSearchStreamerResponse(ctx *fiber.Ctx) error
+}
+
+type SearchStreamer200JSONResponse SearchResponse
+
+func (response SearchStreamer200JSONResponse) VisitSearchStreamerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type SearchStreamer400JSONResponse General
+
+func (response SearchStreamer400JSONResponse) VisitSearchStreamerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type SearchStreamer401JSONResponse General
+
+func (response SearchStreamer401JSONResponse) VisitSearchStreamerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type SearchStreamer403JSONResponse General
+
+func (response SearchStreamer403JSONResponse) VisitSearchStreamerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type SearchStreamer404JSONResponse General
+
+func (response SearchStreamer404JSONResponse) VisitSearchStreamerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type SearchStreamer500JSONResponse General
+
+func (response SearchStreamer500JSONResponse) VisitSearchStreamerResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreamerAliasesRequestObject struct {
+	Login string `json:"login"`
+}
+
+type ListStreamerAliasesResponseObject interface {
+	VisitListStreamerAliasesResponse(ctx *fiber.Ctx) error
+}
+
+type ListStreamerAliases200JSONResponse StreamerAliasListResponse
+
+func (response ListStreamerAliases200JSONResponse) VisitListStreamerAliasesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreamerAliases400JSONResponse General
+
+func (response ListStreamerAliases400JSONResponse) VisitListStreamerAliasesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreamerAliases401JSONResponse General
+
+func (response ListStreamerAliases401JSONResponse) VisitListStreamerAliasesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreamerAliases403JSONResponse General
+
+func (response ListStreamerAliases403JSONResponse) VisitListStreamerAliasesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreamerAliases404JSONResponse General
+
+func (response ListStreamerAliases404JSONResponse) VisitListStreamerAliasesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreamerAliases500JSONResponse General
+
+func (response ListStreamerAliases500JSONResponse) VisitListStreamerAliasesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type CreateStreamerAliasRequestObject struct {
+	Login string `json:"login"`
+	Body  *CreateStreamerAliasJSONRequestBody
+}
+
+type CreateStreamerAliasResponseObject interface {
+	VisitCreateStreamerAliasResponse(ctx *fiber.Ctx) error
+}
+
+type CreateStreamerAlias200JSONResponse StreamerAlias
+
+func (response CreateStreamerAlias200JSONResponse) VisitCreateStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateStreamerAlias400JSONResponse General
+
+func (response CreateStreamerAlias400JSONResponse) VisitCreateStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type CreateStreamerAlias401JSONResponse General
+
+func (response CreateStreamerAlias401JSONResponse) VisitCreateStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type CreateStreamerAlias403JSONResponse General
+
+func (response CreateStreamerAlias403JSONResponse) VisitCreateStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type CreateStreamerAlias404JSONResponse General
+
+func (response CreateStreamerAlias404JSONResponse) VisitCreateStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type CreateStreamerAlias500JSONResponse General
+
+func (response CreateStreamerAlias500JSONResponse) VisitCreateStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteStreamerAliasRequestObject struct {
+	Login string `json:"login"`
+	Id    int64  `json:"id"`
+}
+
+type DeleteStreamerAliasResponseObject interface {
+	VisitDeleteStreamerAliasResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteStreamerAlias204Response struct {
+}
+
+func (response DeleteStreamerAlias204Response) VisitDeleteStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteStreamerAlias400JSONResponse General
+
+func (response DeleteStreamerAlias400JSONResponse) VisitDeleteStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteStreamerAlias401JSONResponse General
+
+func (response DeleteStreamerAlias401JSONResponse) VisitDeleteStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteStreamerAlias403JSONResponse General
+
+func (response DeleteStreamerAlias403JSONResponse) VisitDeleteStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteStreamerAlias404JSONResponse General
+
+func (response DeleteStreamerAlias404JSONResponse) VisitDeleteStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteStreamerAlias500JSONResponse General
+
+func (response DeleteStreamerAlias500JSONResponse) VisitDeleteStreamerAliasResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreamsRequestObject struct {
+	Params ListStreamsParams
+}
+
+type ListStreamsResponseObject interface {
+	VisitListStreamsResponse(ctx *fiber.Ctx) error
+}
+
+type ListStreams200JSONResponse StreamListResponse
+
+func (response ListStreams200JSONResponse) VisitListStreamsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreams400JSONResponse General
+
+func (response ListStreams400JSONResponse) VisitListStreamsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreams401JSONResponse General
+
+func (response ListStreams401JSONResponse) VisitListStreamsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreams403JSONResponse General
+
+func (response ListStreams403JSONResponse) VisitListStreamsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreams404JSONResponse General
+
+func (response ListStreams404JSONResponse) VisitListStreamsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ListStreams500JSONResponse General
+
+func (response ListStreams500JSONResponse) VisitListStreamsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetStreamRequestObject struct {
+	Id     string `json:"id"`
+	Params GetStreamParams
+}
+
+type GetStreamResponseObject interface {
+	VisitGetStreamResponse(ctx *fiber.Ctx) error
+}
+
+type GetStream200JSONResponse StreamDetails
+
+func (response GetStream200JSONResponse) VisitGetStreamResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetStream400JSONResponse General
+
+func (response GetStream400JSONResponse) VisitGetStreamResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type GetStream401JSONResponse General
+
+func (response GetStream401JSONResponse) VisitGetStreamResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetStream403JSONResponse General
+
+func (response GetStream403JSONResponse) VisitGetStreamResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetStream404JSONResponse General
+
+func (response GetStream404JSONResponse) VisitGetStreamResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetStream500JSONResponse General
+
+func (response GetStream500JSONResponse) VisitGetStreamResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List alert subscriptions
+	// (GET /alerts)
+	ListAlerts(ctx context.Context, request ListAlertsRequestObject) (ListAlertsResponseObject, error)
+	// Create alert subscription
+	// (POST /alerts)
+	CreateAlert(ctx context.Context, request CreateAlertRequestObject) (CreateAlertResponseObject, error)
+	// Delete alert subscription
+	// (DELETE /alerts/{id})
+	DeleteAlert(ctx context.Context, request DeleteAlertRequestObject) (DeleteAlertResponseObject, error)
+	// Update alert subscription
+	// (PUT /alerts/{id})
+	UpdateAlert(ctx context.Context, request UpdateAlertRequestObject) (UpdateAlertResponseObject, error)
+	// List recent alert deliveries
+	// (GET /alerts/{id}/deliveries)
+	ListAlertDeliveries(ctx context.Context, request ListAlertDeliveriesRequestObject) (ListAlertDeliveriesResponseObject, error)
+	// Health check
+	// (GET /healthz)
+	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
+	// Search players
+	// (POST /search)
+	SearchPlayers(ctx context.Context, request SearchPlayersRequestObject) (SearchPlayersResponseObject, error)
+	// Search player sightings history
+	// (POST /search/history)
+	SearchPlayerHistory(ctx context.Context, request SearchPlayerHistoryRequestObject) (SearchPlayerHistoryResponseObject, error)
+	// Find streams that have the streamer in their lobby
+	// (POST /search/streamer)
+	SearchStreamer(ctx context.Context, request SearchStreamerRequestObject) (SearchStreamerResponseObject, error)
+	// List known in-game names of the streamer
+	// (GET /streamers/{login}/aliases)
+	ListStreamerAliases(ctx context.Context, request ListStreamerAliasesRequestObject) (ListStreamerAliasesResponseObject, error)
+	// Add in-game name of the streamer
+	// (POST /streamers/{login}/aliases)
+	CreateStreamerAlias(ctx context.Context, request CreateStreamerAliasRequestObject) (CreateStreamerAliasResponseObject, error)
+	// Delete in-game name of the streamer
+	// (DELETE /streamers/{login}/aliases/{id})
+	DeleteStreamerAlias(ctx context.Context, request DeleteStreamerAliasRequestObject) (DeleteStreamerAliasResponseObject, error)
+	// List tracked streams
+	// (GET /streams)
+	ListStreams(ctx context.Context, request ListStreamsRequestObject) (ListStreamsResponseObject, error)
+	// Get stream state and its latest analyses
+	// (GET /streams/{id})
+	GetStream(ctx context.Context, request GetStreamRequestObject) (GetStreamResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// ListAlerts operation middleware
+func (sh *strictHandler) ListAlerts(ctx *fiber.Ctx) error {
+	var request ListAlertsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAlerts(ctx.UserContext(), request.(ListAlertsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAlerts")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListAlertsResponseObject); ok {
+		if err := validResponse.VisitListAlertsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateAlert operation middleware
+func (sh *strictHandler) CreateAlert(ctx *fiber.Ctx) error {
+	var request CreateAlertRequestObject
+
+	var body CreateAlertJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAlert(ctx.UserContext(), request.(CreateAlertRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAlert")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateAlertResponseObject); ok {
+		if err := validResponse.VisitCreateAlertResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteAlert operation middleware
+func (sh *strictHandler) DeleteAlert(ctx *fiber.Ctx, id int64) error {
+	var request DeleteAlertRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAlert(ctx.UserContext(), request.(DeleteAlertRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAlert")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteAlertResponseObject); ok {
+		if err := validResponse.VisitDeleteAlertResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateAlert operation middleware
+func (sh *strictHandler) UpdateAlert(ctx *fiber.Ctx, id int64) error {
+	var request UpdateAlertRequestObject
+
+	request.Id = id
+
+	var body UpdateAlertJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateAlert(ctx.UserContext(), request.(UpdateAlertRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateAlert")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UpdateAlertResponseObject); ok {
+		if err := validResponse.VisitUpdateAlertResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListAlertDeliveries operation middleware
+func (sh *strictHandler) ListAlertDeliveries(ctx *fiber.Ctx, id int64, params ListAlertDeliveriesParams) error {
+	var request ListAlertDeliveriesRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAlertDeliveries(ctx.UserContext(), request.(ListAlertDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAlertDeliveries")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListAlertDeliveriesResponseObject); ok {
+		if err := validResponse.VisitListAlertDeliveriesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// HealthCheck operation middleware
+func (sh *strictHandler) HealthCheck(ctx *fiber.Ctx) error {
+	var request HealthCheckRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.HealthCheck(ctx.UserContext(), request.(HealthCheckRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "HealthCheck")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(HealthCheckResponseObject); ok {
+		if err := validResponse.VisitHealthCheckResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SearchPlayers operation middleware
+func (sh *strictHandler) SearchPlayers(ctx *fiber.Ctx) error {
+	var request SearchPlayersRequestObject
+
+	var body SearchPlayersJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchPlayers(ctx.UserContext(), request.(SearchPlayersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
 		handler = middleware(handler, "SearchPlayers")
@@ -274,21 +2010,278 @@ func (sh *strictHandler) SearchPlayers(ctx *fiber.Ctx) error {
 	return nil
 }
 
+// SearchPlayerHistory operation middleware
+func (sh *strictHandler) SearchPlayerHistory(ctx *fiber.Ctx) error {
+	var request SearchPlayerHistoryRequestObject
+
+	var body SearchPlayerHistoryJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchPlayerHistory(ctx.UserContext(), request.(SearchPlayerHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchPlayerHistory")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SearchPlayerHistoryResponseObject); ok {
+		if err := validResponse.VisitSearchPlayerHistoryResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SearchStreamer operation middleware
+func (sh *strictHandler) SearchStreamer(ctx *fiber.Ctx) error {
+	var request SearchStreamerRequestObject
+
+	var body SearchStreamerJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchStreamer(ctx.UserContext(), request.(SearchStreamerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchStreamer")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SearchStreamerResponseObject); ok {
+		if err := validResponse.VisitSearchStreamerResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListStreamerAliases operation middleware
+func (sh *strictHandler) ListStreamerAliases(ctx *fiber.Ctx, login string) error {
+	var request ListStreamerAliasesRequestObject
+
+	request.Login = login
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListStreamerAliases(ctx.UserContext(), request.(ListStreamerAliasesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListStreamerAliases")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListStreamerAliasesResponseObject); ok {
+		if err := validResponse.VisitListStreamerAliasesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateStreamerAlias operation middleware
+func (sh *strictHandler) CreateStreamerAlias(ctx *fiber.Ctx, login string) error {
+	var request CreateStreamerAliasRequestObject
+
+	request.Login = login
+
+	var body CreateStreamerAliasJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateStreamerAlias(ctx.UserContext(), request.(CreateStreamerAliasRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateStreamerAlias")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateStreamerAliasResponseObject); ok {
+		if err := validResponse.VisitCreateStreamerAliasResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteStreamerAlias operation middleware
+func (sh *strictHandler) DeleteStreamerAlias(ctx *fiber.Ctx, login string, id int64) error {
+	var request DeleteStreamerAliasRequestObject
+
+	request.Login = login
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteStreamerAlias(ctx.UserContext(), request.(DeleteStreamerAliasRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteStreamerAlias")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteStreamerAliasResponseObject); ok {
+		if err := validResponse.VisitDeleteStreamerAliasResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListStreams operation middleware
+func (sh *strictHandler) ListStreams(ctx *fiber.Ctx, params ListStreamsParams) error {
+	var request ListStreamsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListStreams(ctx.UserContext(), request.(ListStreamsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListStreams")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListStreamsResponseObject); ok {
+		if err := validResponse.VisitListStreamsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetStream operation middleware
+func (sh *strictHandler) GetStream(ctx *fiber.Ctx, id string, params GetStreamParams) error {
+	var request GetStreamRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetStream(ctx.UserContext(), request.(GetStreamRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetStream")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetStreamResponseObject); ok {
+		if err := validResponse.VisitGetStreamResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xWQVPbPBD9K579vmMah0IvvhVaWnroMKSdHhgOiryJBbYkViumhvF/70hKIIndcElz",
-	"4hSNdvXe29VbxU8gTWONRs0OiidwssJGxOUX1EiiDktLxiKxwhhAIkNhwa1FKGBmTI1CQzeCxi3WAo5J",
-	"6UXYdyzYuzNT4lpYacYFEozg9zvTKMbGcgsFk8euGwHhvVeEJRTXS8qEv4F2M1qhmdktSg5kX1HUXF2h",
-	"s0Y77OufeVWXnwTjoNQHJKeMHohtaVoljtYAh+RMUZCsrvDeo+O+mnuP1L7OltJ24f+t3FKwCL+hwXHj",
-	"f8I5FPBf/nL1+fLe8ykTiibALnkEkWh7aiLmoJh0vidCi2a43VrJuxB0GxJ7aTvVRPB1qL6y4EGUnhS3",
-	"01Bq4rtEapQLt5iMgYKQzg01gqGAb79+BLPF9GDzGIVn6IrZQheQlZ6bcF4azULyS71QtRZpbqR3sQjF",
-	"9eZu9vHyAtY8B0fjyXgSco1FLayCAo7Hk/EJjMAKrqLMvIoGfwzrBUa60GrByuiLEorlAJxVKO8gdCo5",
-	"I559P5mslKKOR4W1tZLxcH7rkvGTG17zytacxVaU6CQpy6maKdKDkpgplyXNLcScufA1703H6qHaLcDr",
-	"ZwnBDL5pRJi7ZbMyGbsVQrmLAxU9bNxAe9PAXdaiRXKQrIiOT03Z7q2mzUej23R8fCH/4cVuvShDffVS",
-	"ooumPtkj8Y6bPBVl9tyOwHp0CNafWniuDKlHLBPt8SFozw3NVFmiTpwnh+D8bjg7N17HOj8c5lIvNCNp",
-	"UWdhTpGyz/FvfnM+kxkzuxy3Lj3lId1Bcf20hZjeU081FJA/HEF3E74u4udDHJM3t765dd9u7f4EAAD/",
-	"/y7p5ItFCwAA",
+	"H4sIAAAAAAAC/+xdW2/buJf/KgR3H2YBJnHazmLhtzSdTrs7l6LpbB9mgwEtHVucSKRKUnbcIt99wYsk",
+	"SqJsuU3SDP5+qqMLeXjO+Z0bD9UvOBFFKThwrfD8C1ZJBgW1Py9ykNr8KKUoQWoG9nKSUc4ht7+ZhsL+",
+	"+HcJSzzH/3bWjnbmhzqz41y6t/AdwXpbAp5jKiXdmr8TwZdMFm+Yo2ApZEE1nmPG9fNnuHmecQ0rkMEb",
+	"HxlPxWbqOxKohrTzdEo1nGhWQPuG0pLxlXkBOF3k7gV/byFEDpSbmyztT/ufL6LTfqpAMugyazBVnyMS",
+	"PlVMwq+VrmgeJ0BpCbQAGR2vKtND1trOmOL5n2ZtwfgtI9rFkFYJuuLri6a/lFYMLZHXDT1i8Tck2iyg",
+	"ozIDDZzMfE3lCvQOlk9X3A/m+Sin7EjNXPtW8x4+VaAisFKQSEdrCiqRrNRMcDzHPwMHyRK0gUUmxA1S",
+	"bMUZXyH/fERx21V3R/qwYTrJkBcdQRpyWElamCsasZSglKlEyBQJiVa9aSuZR+e6DzbaQfYx7oOfCXhV",
+	"2JfsagxNfhmYYE8/JtiTja8jNNtRX0HO1iC3o/btw1et7GvsjJQiDmNVJQkoFTcATspX42agx+RwWYO3",
+	"27naBYwKpGbdL0zp96BKwRUM2ZhSTQ9zEY1IBhaxtxQ79Ch5903WN5IzCvjQj3aB+pvQbMkSav6s4aoI",
+	"SmFJq1wrpAXSDZY1EkukM0CBxT7YLddE7vfOngg8Pyd9qqtiAdJQQznNt58BJdskB9UhDhWV0mgBKAUN",
+	"iYYUMY4WsBQS7HPUEIWWTFovM3DrBeOsMPg/nxQWjBFoZsqpBqX7tAarRVQCSkTFHZVd/ned3oF0BpFF",
+	"w04tKyARmN9P/NBMs6S5gr7kfuf51nN+kwG37HEGwssN0VwJpDKx6UmTcWQ4lYvFYovJnjClYPwX4Cud",
+	"hWwZsVaBMtcciCHspah4yvjqpbgdyvudUMz8rCXOWXLDaQFIuCWez/5rVqKlNJcYRyW7dQFNF6YZsFUW",
+	"xhCBIDcs1Vn81m388jZ2ubf2W2yeq0cnNQWx9b8GSEfCdCvPq11RYkF1kl0lQkLXWYlqkQeeilvYNC9A",
+	"+pvnY3xQUIquRu5ZfTxkxr1OjmDjVpWmRfmV0W6XTxHPWK+oS3848ahkjG1RLGLhfzGAQRISseLsM6RG",
+	"JSlaSlCZgaKzSanTzYFG1rcv9PQgY1ReNSgOtDBlRlUnHGtMgGQ2zjeBGCwEtdFYAbzCBFf8hosNjwZl",
+	"ZU63IKcnlU5A7+xbez00d2wMGFevIGRAS8SYQC9FUVCeDuX5q9MRpIBrtNha85LkzPwl1iDt32fr87ON",
+	"QibGQUuAdCjXxI3WMlVVCzPLAizz2r9iDNSiZElE1Zwlnf9fNZs9T3KxYtz+BNLYQ3/P2Nmtu2cSAIsL",
+	"Rfy/iAOkClGkxQ1wtGE6s4uSQNO5f6QEWTClzLRksir10eh40CxnTBI/rYHraXJQII0IDpBDbVB36V9r",
+	"ee+8Ynmg732pfvaO4FSKsoR0X6gCZrEK+aeRYjxx0VIpYc1E1d6yT0ZTYWu7p9D3q32wa8m7xP0kpZCo",
+	"NYtxVYzkn+ayi/I8Dxo9QxuqUOrif2P3hMQHqPhlJaURuAeIvaxq5jkYEqcSVm8bHKWI8rTV5fB1ZLMy",
+	"dYAit7lwD3/BbImxfzmkKzBQSpw1IY30NOS5CbCoDg2IgZgK9UALgVQuNrmxt7WpaFSQeP0lwcy4VbU6",
+	"37ze5xdHk/JWSQZrfQlKB2GWD7Str7P6Z+oW5pI1NeY+bZ92StPHon3tf5izufVa4ZYmZoHcuL6cfXYL",
+	"ZEpTnoBfuFtUzFBOiWLU5Bilx7X+4CRYQT1sjKm2ykMjta5+dSCIrQu1ihOvqa7UpUghEmwSfHsiCqPU",
+	"pd66tKO/BjelG78zWozwN0BznY3n24uK5ekrquN8XoNU3uft1sb6QRIMGCPnCqhMsjdMaSG3o6m31b84",
+	"74xtHSr2laaySbQ1KwBtbJLZTQqfvUCZqKRCdCUwmRiZVVyzPGJkebp3Pi42E6fpcdOtfwL/7qWK4kK1",
+	"K5PEML5SX19PccS5mE/tJ64XGtQGyAVEiljeLozFcqBVpuyg9NTyiSPmPSiTV3/jkg5W1APlea+C/PbF",
+	"WpYNSOmY+q7srMFHJ0gZR1F7DIJa+1/fo0sNElWcLbdG1AlVQJAqaWIcLk/R75fvbemmUqYCowiqvQY6",
+	"QUkuFNRBI6RMNzcJajyKeU5wTRlXrScL3PC9u6ZeXBj1rTZi8O8G/tXo9qPkgm3qFqdW9TLdoPzmqi91",
+	"CMZkIyGWghGLEVrp6ziYHKKmY6lh4NwjBgLZm6Yc9MOMoPNrgs5RAdRLuwlWmEJW0vm2owWHRgs+RIil",
+	"oOSrQwmHsrqAMWpabCp4aFnOvRSd1c43nOZfW9mmy3ucp2EV6dsLQTa4u6QaVkJuh1y80iZ9tgZlSVlu",
+	"Kt9LpG6YzU+M1vvYcDDuQxaEpgv6u5gTx50hoR+zbUgUVYiLZsMhJYgubE7KlojZBDhwI2K5zBm3NatU",
+	"Tc/ZogWu/Rr2CjRleUTBcqr0T3UCsp9H7tGm6j/tpbd8Keo6rl3zsFga7NQoE6ZJUThzDBtzw2yHZYBE",
+	"nsIB4VsXW/uQ61cUkDnOzoZj0WV4WC3BeBtT/2hqIeE23rAodQDGk+nwntvsQlSaIKMnOVOaoE8VzZne",
+	"Eqe5BIlEEmSiugVVtjZY43afPjaEEEP/OMesDkS0j6+qsb0Eo5oXXt0jpSYWxEih+jAV4q7lt4MnmBph",
+	"DaLJGdx9uzjBaxwMc/7GzPaaRIyFsfdiLg+T72uVv2Ost2awAXlpdnEn7Lx5/+wFQFoN7A40FrE54Yyr",
+	"+T12JvRsZ2/VHG71ZSVVzAy5682eKNxqVNIVNLBoZKbcjb0gH0/3QtkMC0Pidt8iw93denvfqsrE3UO+",
+	"q8QnRR4r9LC0MRxOsAQJsz1uIVEzB3h6sjLK3IFPu2sj12xtw6Qblucgo0hSuYhsYLzlKdzWBLz545Wx",
+	"tu0kSJoSkBal8XkLobUoIsX+vlK3CUTAQU/AuNzaes1AdMkYnAi2JZQrAD7dW+X00Dfu1+Duj9HbRQXU",
+	"Es+GcQ6CvMgZjfIvVOR+FsormiNq3gSFMrqG0EaeE0QrLZDgoNBKio2zp8ZxbZHyIqv1x9KNGD+x1t6q",
+	"k3nDK7HR5ybYmICmgxvbJrdpfoUG7Czeh5o7oTNYiUomMM3iepleuVcetw83ALInuY/oZuFT+2w7i7p3",
+	"9+TH/YaCYTjMaCUjVIZDihnNe3unvmo0pLbxhYUpJtigMZ6YKUgqyfT2yvDEUfqu2SF3LhCoBPm61oz/",
+	"/vjBRmTmcTz3d1stybQu8Z0Zmflw2VYiE92WWXC2LUEuRVK57eVu/mE2vJskygRbft+eyqajK223yT/C",
+	"4kokN6AR1X7PnITbkgp4qlDQFWGHlJAAW4NCzSZ9vVGsTpHdAFYoodw1lC0AlVQpszPq6mt2RxQpKKk0",
+	"Kuv2BJUvZ5qLBWjjkc2zrhFhKWTbN6jcC/Vo1Ha0/uUe7I1xalM5nXeZhi7evcXBrhQ+P52dzmxMXgKn",
+	"JcNz/Px0dmrMWEl1ZqV45uY2P33btVFO27z5NrV8V/rCPUKw9Piyjz+bzWox+m4GWpa5b/w8+1u5jTGH",
+	"qUndnB0E390NVODKN/neEfziHueuNy8jM76kKQqaS1/Mzh9j1j84rXQmpK2/22mfP8a0r4VcsDQF7uZ8",
+	"8Rhz/iY0em3iZDPnj48j1Ldcg+Q0R1eutcbXfQKbh+d/9qzdnzhoFcLXd9cEq6ooqNx6iPhGkE4Ph804",
+	"hYqA6tK6twvf6SCdhr0U6fZ+AdWobtd32F3zhwbzEcBHAD8pAG8k0zCCYIfHCIbtqN5Fnn1h6Z1LeXLQ",
+	"MET1K3u9RXUHXi8i2foRDkc4PEU4OEWOwoHgJghVdlhmSDDRJK4rGy7z67obEixzb0ptiCmriNv8w+aC",
+	"R7d5tBNHO/EU7ITD4xS3eea7oxlMyDRftc/GzU3dsOLtTc4KpnFoYpoDY89mBBf01p9mm812n227u35o",
+	"eEcPnx4hf4T8PynVlZAArzPeANiPFBwY05LZvu3Po7bE9XVfZpDcPGTZqtc+HgMyyDVLbMudo3nrSpve",
+	"Pj28CgQEVLwh4S4UqlsFSiy3zK0zZZvwzKzx+kWnlfmBQrFub/Ejx2LxXu2joT4a6kc31A1MnU6iumEj",
+	"AOpZ5k48TAOsPx7xoLDtHWH5LujtHwM5oveI3ieC3qbXQaEauiGcw135Gs/dGd+DEvnaf53ENfcw7pp6",
+	"XBtb76sqdnfTjQ6q3nYsMImaieDrAQ9nIfod/t/FRBxtw9E2PAHb8JoZdFpA+APUtnGq/60a13bqemCd",
+	"ufA31dkXe7Tl7sw3Xu2ssXR6Q+BBt/XHG3SOgDsC7gnWPBwiYkWPCU52WgHEInVnDaTfjHW9u42gA7KH",
+	"8tmxVrbHdtmddR4NyNGAPMV9kqgFuUjTjukYWo6d7nxi58HQEBw7EI6I+Wcixrcg7AbNw7hb8oD7Fo72",
+	"KfF5ZO+zy/T2lFTNmOZ7Wu5EFNOm6G+P/6xpzsJPRRluKiHtcdPIhmpih8VTmPRgW7FksF5D9AmieZnR",
+	"BWiW0DzfEuROtil0ggqhzEFk9x0Je3iEoPCcZf2I277qfKiw/nJKZEWeTZEFuSfag1P+T08Q7h3yvCZT",
+	"edic3xtwvzlJOeTOxwx0Zus82+Y7DwptQMKeE40jy86oard3dhMSe705yr3UIHEUKDtPkuwe9aX94u5X",
+	"Dds/UGxPz9YHJU3rvYRSSA2p/ZSK/UwxQXC6OkXAR1gVHLM8GC8F4//b6Ev7doOMWRwZ0aHo7cFDXT94",
+	"uH5M+o8RyBOpstk0Xkua3EBTbQsD7zbMjrrmn+vP6u5zzOMf6lagfB0P1R9iIOFHPm8ASu+xg1dLKQxE",
+	"GF+d1m/9pdhnQD/8ODNWyjuk/2jnKEG2ZyP/uR1T3c97HI3H0Xh8P+Pxc/s1eaVt0yNP3cfju/iOJCXR",
+	"/8bEpiWx//vg4MxjUC20hNuVxOZ3ZwQrmeM5PlufY4Pg2xP/vdj5lyOQjkC6dyDd/f8AOL3FmqdrAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
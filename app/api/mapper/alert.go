@@ -0,0 +1,69 @@
+package mapper
+
+import (
+	"hyperfocus/app/api"
+	"hyperfocus/app/database"
+	"hyperfocus/app/service/alert"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/rofleksey/meg"
+)
+
+func MapAlert(s alert.Subscription) api.Alert {
+	return api.Alert{
+		Id:            s.ID,
+		Streamer:      s.Streamer,
+		Enabled:       s.Enabled,
+		Queries:       meg.NonNilSlice(s.Queries),
+		Channels:      pie.Map(meg.NonNilSlice(s.Channels), MapAlertChannel),
+		ConfirmHits:   s.ConfirmHits,
+		ConfirmWindow: s.ConfirmWindow,
+		RequireMutual: s.RequireMutual,
+		Created:       s.Created,
+		Updated:       s.Updated,
+	}
+}
+
+func MapAlertRequest(r *api.AlertRequest) alert.SubscriptionParams {
+	var channels []alert.ChannelParams
+	if r.Channels != nil {
+		channels = pie.Map(*r.Channels, func(c api.AlertChannelRequest) alert.ChannelParams {
+			return alert.ChannelParams{
+				Type:   string(c.Type),
+				Target: meg.GetPtrOrZero(c.Target),
+				Secret: meg.GetPtrOrZero(c.Secret),
+			}
+		})
+	}
+
+	// window defaults to the required number of hits, i.e. consecutive detections
+	confirmHits := meg.GetPtrOrDefault(r.ConfirmHits, 1)
+
+	return alert.SubscriptionParams{
+		Streamer:      r.Streamer,
+		Enabled:       meg.GetPtrOrDefault(r.Enabled, true),
+		Queries:       r.Queries,
+		Channels:      channels,
+		ConfirmHits:   confirmHits,
+		ConfirmWindow: meg.GetPtrOrDefault(r.ConfirmWindow, confirmHits),
+		RequireMutual: meg.GetPtrOrZero(r.RequireMutual),
+	}
+}
+
+func MapAlertChannel(c database.AlertChannel) api.AlertChannel {
+	return api.AlertChannel{
+		Id:     c.ID,
+		Type:   api.AlertChannelType(c.Type),
+		Target: c.Target,
+	}
+}
+
+func MapAlertDelivery(d database.AlertDelivery) api.AlertDelivery {
+	return api.AlertDelivery{
+		ChannelType:    api.AlertChannelType(d.ChannelType),
+		TargetStreamer: d.TargetStreamer,
+		Success:        d.Success,
+		Error:          d.Error,
+		Created:        d.Created,
+	}
+}
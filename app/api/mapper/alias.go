@@ -0,0 +1,20 @@
+package mapper
+
+import (
+	"hyperfocus/app/api"
+	"hyperfocus/app/database"
+)
+
+func MapStreamerAlias(a database.StreamerAlias) api.StreamerAlias {
+	return api.StreamerAlias{
+		Id:         a.ID,
+		Streamer:   a.Streamer,
+		Nickname:   a.Nickname,
+		Source:     api.StreamerAliasSource(a.Source),
+		Confidence: a.Confidence,
+		Sightings:  a.Sightings,
+		LastSeen:   a.LastSeen,
+		Created:    a.Created,
+		Updated:    a.Updated,
+	}
+}
@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"hyperfocus/app/api"
+	"hyperfocus/app/service/feed"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/rofleksey/meg"
+)
+
+func MapFeedEvent(e feed.Event) api.FeedEvent {
+	result := api.FeedEvent{
+		Topic: &e.Topic,
+	}
+
+	if e.Analysis != nil {
+		result.Type = api.FeedEventTypeAnalysis
+		result.Analysis = &api.FeedAnalysis{
+			Name:       e.Analysis.Stream,
+			AnalyzedAt: e.Analysis.AnalyzedAt,
+			Phase:      api.FeedAnalysisPhase(e.Analysis.Phase),
+			Nicknames:  meg.NonNilSlice(e.Analysis.Nicknames),
+			Players:    pie.Map(meg.NonNilSlice(e.Analysis.Players), MapStreamPlayer),
+		}
+	}
+
+	if e.Match != nil {
+		result.Match = &api.FeedMatch{
+			MatchedNickname: e.Match.Nickname,
+			MatchKind:       api.FeedMatchMatchKind(e.Match.Kind),
+			Score:           e.Match.Score,
+		}
+	}
+
+	if e.Alert != nil {
+		result.Type = api.FeedEventTypeAlert
+		result.Alert = &api.FeedAlert{
+			AlertStreamer:  e.Alert.AlertStreamer,
+			TargetStreamer: e.Alert.TargetStreamer,
+			Message:        e.Alert.Message,
+			MutualScore:    e.Alert.MutualScore,
+			Timestamp:      e.Alert.Timestamp,
+		}
+
+		if e.Alert.MatchedNickname != "" {
+			result.Alert.MatchedNickname = &e.Alert.MatchedNickname
+			result.Alert.MatchScore = &e.Alert.MatchScore
+		}
+	}
+
+	return result
+}
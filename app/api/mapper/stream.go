@@ -3,7 +3,10 @@ package mapper
 import (
 	"hyperfocus/app/api"
 	"hyperfocus/app/database"
+	"hyperfocus/app/service/search"
+	"hyperfocus/app/service/stream"
 
+	"github.com/elliotchance/pie/v2"
 	"github.com/rofleksey/meg"
 )
 
@@ -11,5 +14,100 @@ func MapStream(s database.Stream) api.Stream {
 	return api.Stream{
 		Name:      s.ID,
 		Nicknames: meg.NonNilSlice(s.PlayerNames),
+		Players:   pie.Map(meg.NonNilSlice(s.Players), MapStreamPlayer),
+	}
+}
+
+func MapSearchResult(m search.Match) api.SearchResult {
+	stream := MapStream(m.Stream)
+
+	return api.SearchResult{
+		Name:            stream.Name,
+		Nicknames:       stream.Nicknames,
+		Players:         stream.Players,
+		MatchedNickname: m.Nickname,
+		MatchKind:       api.SearchResultMatchKind(m.Kind),
+		Score:           m.Score,
+	}
+}
+
+func MapStreamInfo(s database.Stream) api.StreamInfo {
+	return api.StreamInfo{
+		Name:         s.ID,
+		Online:       s.Online,
+		Language:     s.Language,
+		ViewerCount:  int(s.ViewerCount),
+		Nicknames:    meg.NonNilSlice(s.PlayerNames),
+		Players:      pie.Map(meg.NonNilSlice(s.Players), MapStreamPlayer),
+		Phase:        api.StreamInfoPhase(s.Phase),
+		LastAnalyzed: s.LastScanAt,
+	}
+}
+
+func MapStreamDetails(d *stream.Details) api.StreamDetails {
+	result := api.StreamDetails{
+		Stream:   MapStreamInfo(d.Stream),
+		Timeline: pie.Map(d.Timeline, MapStreamAnalysis),
+	}
+
+	if d.Stream.LastErrorCategory != nil && d.Stream.LastErrorAt != nil {
+		result.LastError = &api.StreamError{
+			Category: *d.Stream.LastErrorCategory,
+			At:       *d.Stream.LastErrorAt,
+		}
+	}
+
+	return result
+}
+
+func MapStreamAnalysis(a database.StreamAnalysis) api.StreamAnalysis {
+	var skipped *api.StreamAnalysisSkipped
+	if a.SkipReason != "" {
+		reason := api.StreamAnalysisSkipped(a.SkipReason)
+		skipped = &reason
+	}
+
+	return api.StreamAnalysis{
+		AnalyzedAt:    a.AnalyzedAt,
+		Phase:         api.StreamAnalysisPhase(a.Phase),
+		Players:       pie.Map(meg.NonNilSlice(a.Players), MapStreamPlayer),
+		Skipped:       skipped,
+		ErrorCategory: a.ErrorCategory,
+	}
+}
+
+func MapStreamPlayer(p database.StreamPlayer) api.StreamPlayer {
+	var box *api.BoundingBox
+	if len(p.Box) == 4 {
+		box = &api.BoundingBox{
+			X:      int(p.Box[0]),
+			Y:      int(p.Box[1]),
+			Width:  int(p.Box[2]),
+			Height: int(p.Box[3]),
+		}
+	}
+
+	var role *api.StreamPlayerRole
+	if p.Role != "" {
+		value := api.StreamPlayerRole(p.Role)
+		role = &value
+	}
+
+	return api.StreamPlayer{
+		Nickname:   p.Nickname,
+		Confidence: p.Confidence,
+		Slot:       p.Slot,
+		Role:       role,
+		Box:        box,
+	}
+}
+
+func MapStreamSightings(s database.SearchSightingsByNicknameRow) api.StreamSightings {
+	return api.StreamSightings{
+		Name:      s.StreamID,
+		Nicknames: meg.NonNilSlice(s.Nicknames),
+		FirstSeen: s.FirstSeen,
+		LastSeen:  s.LastSeen,
+		Count:     int(s.SightingsCount),
 	}
 }
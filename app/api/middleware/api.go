@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"hyperfocus/app/api"
+	"hyperfocus/app/service/auth"
 	"net/http"
 
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/gofiber/fiber/v2"
 	fm "github.com/oapi-codegen/fiber-middleware"
 	"github.com/samber/do"
@@ -16,8 +18,13 @@ func NewOpenAPIValidator(di *do.Injector) fiber.Handler {
 		panic(oops.Errorf("Failed to get swagger spec: %w", err))
 	}
 
+	authService := do.MustInvoke[*auth.Service](di)
+
 	return fm.OapiRequestValidatorWithOptions(spec,
 		&fm.Options{
+			Options: openapi3filter.Options{
+				AuthenticationFunc: newAuthenticationFunc(authService),
+			},
 			ErrorHandler: func(c *fiber.Ctx, message string, _ int) {
 				c.Status(fiber.StatusForbidden).JSON(api.General{ //nolint:errcheck
 					Error:      true,
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hyperfocus/app/service/auth"
+	"hyperfocus/app/util"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gofiber/fiber/v2"
+	fm "github.com/oapi-codegen/fiber-middleware"
+)
+
+var errMissingBearerToken = errors.New("missing bearer token")
+var errPermissionDenied = errors.New("permission denied")
+
+// newAuthenticationFunc checks bearer tokens of the operations that declare a security requirement
+// and injects the authenticated user into the request context
+func newAuthenticationFunc(authService *auth.Service) openapi3filter.AuthenticationFunc {
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		c := fm.GetFiberContext(ctx)
+		if c == nil {
+			return errors.New("fiber context not found")
+		}
+
+		tokenStr, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || tokenStr == "" {
+			return errMissingBearerToken
+		}
+
+		claims, err := authService.ParseToken(tokenStr)
+		if err != nil {
+			return fmt.Errorf("ParseToken: %w", err)
+		}
+
+		if !authService.IsGranted(claims, input.Scopes...) {
+			return errPermissionDenied
+		}
+
+		userCtx := context.WithValue(c.UserContext(), util.UserContextKey, claims)
+		userCtx = context.WithValue(userCtx, util.UsernameContextKey, claims.Subject)
+		c.SetUserContext(userCtx)
+
+		return nil
+	}
+}
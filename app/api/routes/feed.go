@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"hyperfocus/app/api/controller"
+	"hyperfocus/app/api/middleware"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/samber/do"
+)
+
+// FeedRoutes registers the live feed, it has to be registered before the OpenAPI handlers,
+// otherwise the request validator rejects the route that is missing from the spec
+func FeedRoutes(router fiber.Router, di *do.Injector) {
+	feedController := controller.NewFeedController(di)
+
+	router.Get("/ws", middleware.WebSocketUpgrade(), feedController.Authenticate, websocket.New(feedController.Serve))
+}
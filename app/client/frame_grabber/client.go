@@ -3,15 +3,18 @@ package frame_grabber
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"hyperfocus/app/config"
 	"hyperfocus/app/util"
+	"hyperfocus/app/util/telemetry"
 	"image"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os/exec"
-	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/image/bmp"
@@ -21,9 +24,22 @@ import (
 
 const clientId = "kimne78kx3ncx6brgo4mv6wki5h1ko"
 
+const maxPlaylistSize = 5 * 1024 * 1024
+const maxSegmentSize = 32 * 1024 * 1024
+
+// scaleFilter normalizes the frame height and keeps the aspect ratio, HUD layouts are anchored to the frame edges
+const scaleFilter = "scale=-2:1080"
+
+var ErrNoLiveSegment = errors.New("no live segments in the playlist")
+
 type Client struct {
-	cfg    *config.Config
-	client *http.Client
+	appCtx  context.Context
+	cfg     *config.Config
+	tracing *telemetry.Tracing
+	client  *http.Client
+
+	sessionsMu sync.Mutex
+	sessions   map[string]*Session
 }
 
 func NewClient(di *do.Injector) (*Client, error) {
@@ -35,7 +51,10 @@ func NewClient(di *do.Injector) (*Client, error) {
 	}
 
 	return &Client{
-		cfg: cfg,
+		appCtx:   do.MustInvoke[context.Context](di),
+		cfg:      cfg,
+		tracing:  do.MustInvoke[*telemetry.Tracing](di),
+		sessions: make(map[string]*Session),
 		client: &http.Client{
 			Timeout:   30 * time.Second,
 			Transport: transport,
@@ -43,60 +62,119 @@ func NewClient(di *do.Injector) (*Client, error) {
 	}, nil
 }
 
-func (c *Client) GrabFrameFromM3U8(ctx context.Context, url, proxy string) (image.Image, error) {
-	adDuration, err := c.getAdDuration(ctx, url, proxy)
+// GrabFrameFromM3U8 downloads the newest non-ad segment of the media playlist and decodes its first frame
+func (c *Client) GrabFrameFromM3U8(ctx context.Context, m3u8URL string) (image.Image, error) {
+	ctx, span := c.tracing.StartServiceSpan(ctx, "frame_grabber", "grab_frame")
+	defer span.End()
+
+	playlist, err := c.fetchPlaylist(ctx, m3u8URL)
 	if err != nil {
-		return nil, fmt.Errorf("getAdDuration: %w", err)
+		return nil, c.tracing.Error(span, fmt.Errorf("fetchPlaylist: %w", err))
 	}
 
-	skipTime := ""
-	if adDuration > 0 {
-		skipTime = fmt.Sprintf("%.1f", adDuration+1)
+	segment, ok := playlist.NewestSegment(c.cfg.Twitch.AdsCheck)
+	if !ok {
+		return nil, c.tracing.Error(span, ErrNoLiveSegment)
+	}
+
+	c.tracing.AddAttributes(span, map[string]interface{}{
+		"segment.sequence": segment.Sequence,
+		"segment.ad_count": playlist.AdCount(),
+	})
+
+	if adCount := playlist.AdCount(); adCount > 0 && c.cfg.Twitch.AdsCheck {
 		slog.Debug("Detected ads, skipping...",
-			slog.String("duration", skipTime),
-			slog.String("url", url),
+			slog.Int("ad_segments", adCount),
+			slog.Int64("sequence", segment.Sequence),
+			slog.String("url", m3u8URL),
 		)
 	}
 
-	headers := strings.Join([]string{
-		"User-Agent: Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36",
-		"Accept: */*",
-		"Accept-Language: en-US,en;q=0.5",
-		"Origin: https://www.twitch.tv",
-		"Referer: https://www.twitch.tv/",
-		"Client-Id:" + clientId,
-		"Authorization: OAuth " + c.cfg.Twitch.BrowserOauthToken,
-	}, "\r\n")
+	data, err := c.fetch(ctx, segment.URL, maxSegmentSize)
+	if err != nil {
+		return nil, c.tracing.Error(span, fmt.Errorf("failed to fetch segment: %w", err))
+	}
 
-	args := []string{
-		"-headers", headers,
+	result, err := c.decodeFrame(ctx, data)
+	if err != nil {
+		return nil, c.tracing.Error(span, fmt.Errorf("decodeFrame: %w", err))
 	}
 
-	if proxy != "" {
-		args = append(args, "-http_proxy", proxy)
+	c.tracing.Success(span)
+
+	return result, nil
+}
+
+func (c *Client) fetchPlaylist(ctx context.Context, m3u8URL string) (*MediaPlaylist, error) {
+	baseURL, err := url.Parse(m3u8URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid m3u8 url: %w", err)
 	}
 
-	args = append(args, "-i", url)
+	content, err := c.fetch(ctx, m3u8URL, maxPlaylistSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch m3u8: %w", err)
+	}
 
-	if skipTime != "" {
-		args = append(args, "-ss", skipTime)
+	playlist, err := parseMediaPlaylist(string(content), baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parseMediaPlaylist: %w", err)
 	}
 
-	args = append(args,
-		"-vf", "scale=1920:1080",
+	return playlist, nil
+}
+
+func (c *Client) fetch(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36")
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
+	req.Header.Set("Origin", "https://www.twitch.tv")
+	req.Header.Set("Referer", "https://www.twitch.tv/")
+	req.Header.Set("Client-Id", clientId)
+	req.Header.Set("Authorization", "OAuth "+c.cfg.Twitch.BrowserOauthToken)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	// Read with limit to prevent excessive memory usage, the extra byte tells a truncated response apart
+	content, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("response exceeds %d bytes", limit)
+	}
+
+	return content, nil
+}
+
+// decodeFrame feeds the segment to ffmpeg over stdin and returns its first frame
+func (c *Client) decodeFrame(ctx context.Context, segment []byte) (image.Image, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-i", "pipe:0",
+		"-vf", scaleFilter,
 		"-vframes", "1",
 		"-f", "image2pipe",
 		"-c", "bmp",
 		"-",
-		"-skip_frame", "nokey", // Skip non-keyframes for faster seeking
-		//"-threads", "1", // Use single thread to avoid overhead
-		"-noaccurate_seek",    // Faster but less precise seeking
-		"-flags", "low_delay", // Reduce buffering delays
-		"-avioflags", "direct", // Reduce buffering
-		"-fflags", "nobuffer+flush_packets", // Minimal buffering
 	)
 
-	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stdin = bytes.NewReader(segment)
 
 	var stdout bytes.Buffer
 	cmd.Stdout = &stdout
@@ -104,23 +182,12 @@ func (c *Client) GrabFrameFromM3U8(ctx context.Context, url, proxy string) (imag
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start ffmpeg: %w", err)
-	}
-
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Wait()
-	}()
-
-	select {
-	case <-time.After(30 * time.Second):
-		cmd.Process.Kill()
-		return nil, fmt.Errorf("ffmpeg timeout after 30 seconds")
-	case err := <-done:
-		if err != nil {
-			return nil, fmt.Errorf("ffmpeg execution failed: %w, output: %s", err, stderr.String())
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("ffmpeg timeout: %w", ctx.Err())
 		}
+
+		return nil, fmt.Errorf("ffmpeg execution failed: %w, output: %s", err, stderr.String())
 	}
 
 	output := stdout.Bytes()
@@ -130,7 +197,7 @@ func (c *Client) GrabFrameFromM3U8(ctx context.Context, url, proxy string) (imag
 
 	result, err := bmp.Decode(bytes.NewReader(output))
 	if err != nil {
-		return nil, fmt.Errorf("invalid PNG data from ffmpeg: %w", err)
+		return nil, fmt.Errorf("invalid BMP data from ffmpeg: %w", err)
 	}
 
 	size := result.Bounds().Size()
@@ -140,41 +207,3 @@ func (c *Client) GrabFrameFromM3U8(ctx context.Context, url, proxy string) (imag
 
 	return result, nil
 }
-
-func (c *Client) getAdDuration(ctx context.Context, m3u8URL, proxy string) (float64, error) {
-	if !c.cfg.Twitch.AdsCheck {
-		return 0.0, nil
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", m3u8URL, nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set appropriate headers for m3u8 request
-	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36")
-	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
-	req.Header.Set("Origin", "https://www.twitch.tv")
-	req.Header.Set("Referer", "https://www.twitch.tv/")
-	req.Header.Set("Client-Id", clientId)
-	req.Header.Set("Authorization", "OAuth "+c.cfg.Twitch.BrowserOauthToken)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("failed to fetch m3u8: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("m3u8 fetch failed with status: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
-
-	// Read with limit to prevent excessive memory usage
-	content, err := io.ReadAll(io.LimitReader(resp.Body, 5*1024*1024)) // 5MB limit
-	if err != nil {
-		return 0, fmt.Errorf("failed to read m3u8 content: %w", err)
-	}
-
-	return c.analyzeAds(string(content))
-}
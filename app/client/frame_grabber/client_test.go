@@ -0,0 +1,33 @@
+package frame_grabber
+
+import (
+	"context"
+	"hyperfocus/app/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientFetch_Limit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 16)))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		cfg:    &config.Config{},
+		client: server.Client(),
+	}
+
+	content, err := client.fetch(context.Background(), server.URL, 16)
+	require.NoError(t, err)
+	assert.Len(t, content, 16)
+
+	// a truncated segment would be decoded as a corrupt one
+	_, err = client.fetch(context.Background(), server.URL, 15)
+	require.Error(t, err)
+}
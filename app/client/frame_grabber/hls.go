@@ -0,0 +1,318 @@
+package frame_grabber
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ad signals of the twitch playlists, ad segments don't always have a non-live EXTINF title
+const (
+	stitchedAdClass       = "twitch-stitched-ad"
+	adQuartileClass       = "twitch-ad-quartile"
+	streamSourceClass     = "twitch-stream-source"
+	adRollTypeAttribute   = "X-TV-TWITCH-AD-ROLL-TYPE"
+	adQuartileAttribute   = "X-TV-TWITCH-AD-QUARTILE"
+	streamSourceAttribute = "X-TV-TWITCH-STREAM-SOURCE"
+	lastAdQuartile        = "4"
+	liveSegmentName       = "live"
+)
+
+// defaultAdDuration is assumed for the ad date ranges without DURATION, e.g. prerolls
+const defaultAdDuration = 15 * time.Second
+
+// MediaPlaylist is a parsed HLS media playlist
+type MediaPlaylist struct {
+	TargetDuration float64
+	MediaSequence  int64
+	Segments       []Segment
+	DateRanges     []DateRange
+}
+
+type Segment struct {
+	URL      string
+	Sequence int64
+	Duration float64
+	// Title is the EXTINF title, twitch sets it to "live" for the stream content
+	Title           string
+	ProgramDateTime time.Time
+	Discontinuity   bool
+	Ad              bool
+}
+
+// DateRange is an EXT-X-DATERANGE tag, twitch uses them to mark stitched ads
+type DateRange struct {
+	ID        string
+	Class     string
+	StartDate time.Time
+	Duration  float64
+	// EndOnNext ranges last until the next range of the same class starts
+	EndOnNext  bool
+	Attributes map[string]string
+}
+
+// isAd reports whether the range marks an ad: stitched ads, their quartiles, prerolls and non-live stream sources
+func (r DateRange) isAd() bool {
+	switch {
+	case r.Class == stitchedAdClass, r.Class == adQuartileClass:
+		return true
+	case r.Attributes[adRollTypeAttribute] != "":
+		return true
+	case r.Class == streamSourceClass:
+		return r.Attributes[streamSourceAttribute] != liveSegmentName
+	default:
+		return false
+	}
+}
+
+// timeWindow is a time interval, a zero End means it is still open
+type timeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (w timeWindow) contains(t time.Time) bool {
+	if t.IsZero() || w.Start.IsZero() {
+		return false
+	}
+
+	return !t.Before(w.Start) && (w.End.IsZero() || t.Before(w.End))
+}
+
+// parseMediaPlaylist parses the playlist and resolves segment URLs relative to baseURL
+func parseMediaPlaylist(content string, baseURL *url.URL) (*MediaPlaylist, error) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "#EXTM3U" {
+		return nil, fmt.Errorf("missing #EXTM3U header")
+	}
+
+	var playlist MediaPlaylist
+	var next Segment
+	var hasInf bool
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		tag, value, _ := strings.Cut(line, ":")
+
+		switch {
+		case tag == "#EXT-X-TARGETDURATION":
+			duration, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid target duration '%s': %w", value, err)
+			}
+			playlist.TargetDuration = duration
+		case tag == "#EXT-X-MEDIA-SEQUENCE":
+			sequence, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid media sequence '%s': %w", value, err)
+			}
+			playlist.MediaSequence = sequence
+		case tag == "#EXT-X-DATERANGE":
+			dateRange, err := parseDateRange(value)
+			if err != nil {
+				return nil, fmt.Errorf("parseDateRange: %w", err)
+			}
+			playlist.DateRanges = append(playlist.DateRanges, dateRange)
+		case tag == "#EXT-X-DISCONTINUITY":
+			next.Discontinuity = true
+		case tag == "#EXT-X-PROGRAM-DATE-TIME":
+			programDateTime, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid program date time '%s': %w", value, err)
+			}
+			next.ProgramDateTime = programDateTime
+		case tag == "#EXTINF":
+			durationStr, title, _ := strings.Cut(value, ",")
+			duration, err := strconv.ParseFloat(durationStr, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid segment duration '%s': %w", durationStr, err)
+			}
+			next.Duration = duration
+			next.Title = title
+			hasInf = true
+		case strings.HasPrefix(line, "#"):
+			// unsupported tag or comment
+		default:
+			if !hasInf {
+				return nil, fmt.Errorf("segment uri without #EXTINF: %s", line)
+			}
+
+			segmentURL, err := baseURL.Parse(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid segment uri '%s': %w", line, err)
+			}
+
+			next.URL = segmentURL.String()
+			next.Sequence = playlist.MediaSequence + int64(len(playlist.Segments))
+			playlist.Segments = append(playlist.Segments, next)
+
+			next = Segment{}
+			hasInf = false
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	playlist.markAds()
+
+	return &playlist, nil
+}
+
+// markAds marks segments that are inside ad date ranges or are not live content
+func (p *MediaPlaylist) markAds() {
+	windows := p.adWindows()
+
+	for i := range p.Segments {
+		segment := &p.Segments[i]
+
+		if segment.Title != "" && segment.Title != liveSegmentName {
+			segment.Ad = true
+			continue
+		}
+
+		for _, window := range windows {
+			if window.contains(segment.ProgramDateTime) {
+				segment.Ad = true
+				break
+			}
+		}
+	}
+}
+
+// adWindows returns the time windows of the ad date ranges
+func (p *MediaPlaylist) adWindows() []timeWindow {
+	var result []timeWindow
+
+	for i, dateRange := range p.DateRanges {
+		if !dateRange.isAd() {
+			continue
+		}
+
+		window := timeWindow{Start: dateRange.StartDate}
+
+		switch {
+		case dateRange.Class == adQuartileClass && dateRange.Attributes[adQuartileAttribute] != lastAdQuartile:
+			// quartiles mark the progress of the ad, it plays at least until the next one
+			window.End = p.nextStartDate(i)
+		case dateRange.EndOnNext:
+			window.End = p.nextStartDate(i)
+		case dateRange.Duration > 0:
+			window.End = dateRange.StartDate.Add(time.Duration(dateRange.Duration * float64(time.Second)))
+		default:
+			window.End = dateRange.StartDate.Add(defaultAdDuration)
+		}
+
+		result = append(result, window)
+	}
+
+	return result
+}
+
+// nextStartDate returns the start of the first range after the index with the same class, zero if there is none
+func (p *MediaPlaylist) nextStartDate(index int) time.Time {
+	for _, dateRange := range p.DateRanges[index+1:] {
+		if dateRange.Class == p.DateRanges[index].Class && dateRange.StartDate.After(p.DateRanges[index].StartDate) {
+			return dateRange.StartDate
+		}
+	}
+
+	return time.Time{}
+}
+
+// NewestSegment returns the most recent segment, skipping ads if requested
+func (p *MediaPlaylist) NewestSegment(skipAds bool) (Segment, bool) {
+	for i := len(p.Segments) - 1; i >= 0; i-- {
+		if skipAds && p.Segments[i].Ad {
+			continue
+		}
+
+		return p.Segments[i], true
+	}
+
+	return Segment{}, false
+}
+
+// AdCount returns the number of ad segments in the playlist
+func (p *MediaPlaylist) AdCount() int {
+	var count int
+
+	for _, segment := range p.Segments {
+		if segment.Ad {
+			count++
+		}
+	}
+
+	return count
+}
+
+func parseDateRange(value string) (DateRange, error) {
+	attributes := parseAttributeList(value)
+
+	result := DateRange{
+		ID:         attributes["ID"],
+		Class:      attributes["CLASS"],
+		EndOnNext:  attributes["END-ON-NEXT"] == "YES",
+		Attributes: attributes,
+	}
+
+	if startDate, ok := attributes["START-DATE"]; ok {
+		parsed, err := time.Parse(time.RFC3339Nano, startDate)
+		if err != nil {
+			return DateRange{}, fmt.Errorf("invalid START-DATE '%s': %w", startDate, err)
+		}
+		result.StartDate = parsed
+	}
+
+	if duration, ok := attributes["DURATION"]; ok {
+		parsed, err := strconv.ParseFloat(duration, 64)
+		if err != nil {
+			return DateRange{}, fmt.Errorf("invalid DURATION '%s': %w", duration, err)
+		}
+		result.Duration = parsed
+	}
+
+	return result, nil
+}
+
+// parseAttributeList parses KEY=VALUE,KEY="quoted, value" lists, quotes are removed
+func parseAttributeList(value string) map[string]string {
+	result := make(map[string]string)
+
+	for len(value) > 0 {
+		key, rest, found := strings.Cut(value, "=")
+		if !found {
+			break
+		}
+
+		var attrValue string
+
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end == -1 {
+				attrValue = rest[1:]
+				rest = ""
+			} else {
+				attrValue = rest[1 : end+1]
+				rest = rest[end+2:]
+			}
+		} else {
+			attrValue, rest, _ = strings.Cut(rest, ",")
+		}
+
+		result[strings.TrimSpace(key)] = attrValue
+		value = strings.TrimPrefix(rest, ",")
+	}
+
+	return result
+}
@@ -0,0 +1,113 @@
+package frame_grabber
+
+import (
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMediaPlaylist(t *testing.T) {
+	tests := []struct {
+		filename         string
+		wantSegments     int
+		wantAds          int
+		wantAdDuration   float64
+		wantLive         bool
+		wantLiveSequence int64
+	}{
+		{
+			filename:       "test_dataset/ads.m3u8",
+			wantSegments:   3,
+			wantAds:        3,
+			wantAdDuration: 15.235,
+			wantLive:       false,
+		},
+		{
+			filename:         "test_dataset/ads_1.m3u8",
+			wantSegments:     21,
+			wantAds:          8,
+			wantAdDuration:   15.235,
+			wantLive:         true,
+			wantLiveSequence: 20,
+		},
+		// ads_1.m3u8 with live EXTINF titles and a single ad signal left
+		{
+			filename:         "test_dataset/ads_quartile.m3u8",
+			wantSegments:     21,
+			wantAds:          8,
+			wantLive:         true,
+			wantLiveSequence: 20,
+		},
+		{
+			filename:         "test_dataset/ads_preroll.m3u8",
+			wantSegments:     21,
+			wantAds:          8,
+			wantLive:         true,
+			wantLiveSequence: 20,
+		},
+		{
+			filename:         "test_dataset/ads_source.m3u8",
+			wantSegments:     21,
+			wantAds:          8,
+			wantLive:         true,
+			wantLiveSequence: 20,
+		},
+		{
+			filename:         "test_dataset/no_ads.m3u8",
+			wantSegments:     15,
+			wantAds:          0,
+			wantAdDuration:   0,
+			wantLive:         true,
+			wantLiveSequence: 1692,
+		},
+	}
+
+	baseURL, err := url.Parse("https://example.com/v1/playlist/index.m3u8")
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			content, err := os.ReadFile(tt.filename)
+			require.NoError(t, err)
+
+			playlist, err := parseMediaPlaylist(string(content), baseURL)
+			require.NoError(t, err)
+
+			assert.Len(t, playlist.Segments, tt.wantSegments)
+			assert.Equal(t, tt.wantAds, playlist.AdCount())
+
+			var adDuration float64
+			for _, dateRange := range playlist.DateRanges {
+				if dateRange.Class == stitchedAdClass {
+					adDuration = dateRange.Duration
+				}
+			}
+			assert.Equal(t, tt.wantAdDuration, adDuration)
+
+			segment, ok := playlist.NewestSegment(true)
+			assert.Equal(t, tt.wantLive, ok)
+			if ok {
+				assert.False(t, segment.Ad)
+				assert.Equal(t, tt.wantLiveSequence, segment.Sequence)
+			}
+
+			newest, ok := playlist.NewestSegment(false)
+			require.True(t, ok)
+			assert.Equal(t, playlist.Segments[len(playlist.Segments)-1], newest)
+		})
+	}
+}
+
+func TestParseAttributeList(t *testing.T) {
+	attributes := parseAttributeList(`ID="a,b",CLASS="twitch-stitched-ad",DURATION=15.235,END-ON-NEXT=YES`)
+
+	assert.Equal(t, map[string]string{
+		"ID":          "a,b",
+		"CLASS":       "twitch-stitched-ad",
+		"DURATION":    "15.235",
+		"END-ON-NEXT": "YES",
+	}, attributes)
+}
@@ -0,0 +1,142 @@
+package frame_grabber
+
+import (
+	"context"
+	"image"
+	"log/slog"
+	"time"
+
+	"github.com/rofleksey/meg"
+)
+
+// OpenSession starts a long-lived frame session for the stream, if it's not running yet.
+// The least recently read session is closed if the pool is full.
+func (c *Client) OpenSession(key, m3u8URL string) error {
+	maxCount := c.cfg.Processing.Sessions.MaxCount
+	if maxCount <= 0 {
+		return ErrSessionsDisabled
+	}
+
+	c.sessionsMu.Lock()
+
+	var evicted []*Session
+
+	if existing, ok := c.sessions[key]; ok {
+		if existing.m3u8URL == m3u8URL {
+			c.sessionsMu.Unlock()
+			return nil
+		}
+
+		delete(c.sessions, key)
+		evicted = append(evicted, existing)
+	}
+
+	for len(c.sessions) >= maxCount {
+		var oldestKey string
+		var oldestTime time.Time
+
+		for otherKey, session := range c.sessions {
+			if idleSince := session.idleSince(); oldestKey == "" || idleSince.Before(oldestTime) {
+				oldestKey = otherKey
+				oldestTime = idleSince
+			}
+		}
+
+		evicted = append(evicted, c.sessions[oldestKey])
+		delete(c.sessions, oldestKey)
+	}
+
+	ctx, cancel := context.WithCancel(c.appCtx)
+
+	session := &Session{
+		key:        key,
+		m3u8URL:    m3u8URL,
+		client:     c,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+		lastAccess: time.Now(),
+	}
+
+	c.sessions[key] = session
+
+	c.sessionsMu.Unlock()
+
+	for _, old := range evicted {
+		old.close()
+	}
+
+	go session.run(ctx, func() {
+		c.removeSession(session)
+	})
+
+	return nil
+}
+
+// LatestFrame returns the latest frame of the stream session if it's not older than maxAge
+func (c *Client) LatestFrame(key string, maxAge time.Duration) (image.Image, bool) {
+	c.sessionsMu.Lock()
+	session, ok := c.sessions[key]
+	c.sessionsMu.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	frame, frameTime := session.LatestFrame()
+	if frame == nil || time.Since(frameTime) > maxAge {
+		return nil, false
+	}
+
+	return frame, true
+}
+
+// HasSession returns whether the stream session is running
+func (c *Client) HasSession(key string) bool {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+
+	_, ok := c.sessions[key]
+
+	return ok
+}
+
+func (c *Client) removeSession(session *Session) {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+
+	if c.sessions[session.key] == session {
+		delete(c.sessions, session.key)
+	}
+}
+
+func (c *Client) evictIdleSessions() {
+	idleTimeout := time.Duration(c.cfg.Processing.Sessions.IdleTimeout) * time.Second
+
+	c.sessionsMu.Lock()
+
+	var evicted []*Session
+
+	for key, session := range c.sessions {
+		if time.Since(session.idleSince()) > idleTimeout {
+			evicted = append(evicted, session)
+			delete(c.sessions, key)
+		}
+	}
+
+	c.sessionsMu.Unlock()
+
+	for _, session := range evicted {
+		slog.Debug("Closing idle frame session",
+			slog.String("key", session.key),
+		)
+		session.close()
+	}
+}
+
+// RunSessionEvictionLoop closes idle sessions until ctx is done
+func (c *Client) RunSessionEvictionLoop(ctx context.Context) {
+	interval := time.Duration(c.cfg.Processing.Sessions.IdleTimeout) * time.Second / 4
+	meg.RunTicker(ctx, interval, func() {
+		c.evictIdleSessions()
+	})
+}
@@ -0,0 +1,260 @@
+package frame_grabber
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/image/bmp"
+)
+
+// maxSessionFailures is the number of consecutive failed runs after which the session is closed,
+// usually it means that the playlist url has expired and has to be resolved again
+const maxSessionFailures = 3
+
+const sessionRestartDelay = 5 * time.Second
+
+const bmpHeaderSize = 14
+
+var ErrSessionsDisabled = errors.New("frame sessions are disabled")
+
+// Session keeps a persistent decoder for a single stream: segments of the media playlist
+// are fed into ffmpeg as they appear, and every decoded keyframe replaces the latest frame.
+type Session struct {
+	key     string
+	m3u8URL string
+	client  *Client
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu         sync.Mutex
+	frame      image.Image
+	frameTime  time.Time
+	lastAccess time.Time
+	lastErr    error
+}
+
+// LatestFrame returns the latest decoded frame and its time, frame is nil if nothing was decoded yet
+func (s *Session) LatestFrame() (image.Image, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastAccess = time.Now()
+
+	return s.frame, s.frameTime
+}
+
+func (s *Session) setFrame(frame image.Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.frame = frame
+	s.frameTime = time.Now()
+}
+
+func (s *Session) idleSince() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastAccess
+}
+
+func (s *Session) close() {
+	s.cancel()
+	<-s.done
+}
+
+func (s *Session) run(ctx context.Context, onExit func()) {
+	defer close(s.done)
+	defer onExit()
+
+	failures := 0
+
+	for failures < maxSessionFailures {
+		decoded, err := s.runDecoder(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if decoded {
+			failures = 0
+		}
+		failures++
+
+		s.mu.Lock()
+		s.lastErr = err
+		s.mu.Unlock()
+
+		slog.Debug("Frame session stopped, restarting",
+			slog.String("key", s.key),
+			slog.Int("failures", failures),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(sessionRestartDelay):
+		}
+	}
+
+	slog.Warn("Frame session failed too many times, closing",
+		slog.String("key", s.key),
+		slog.Any("error", s.lastErr),
+	)
+}
+
+// runDecoder runs a single ffmpeg process until it fails, returns whether any frame was decoded
+func (s *Session) runDecoder(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	interval := s.client.cfg.Processing.Sessions.FrameInterval
+
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-loglevel", "error",
+		"-skip_frame", "nokey",
+		"-i", "pipe:0",
+		"-vf", "fps=1/"+strconv.Itoa(interval)+","+scaleFilter,
+		"-f", "image2pipe",
+		"-c", "bmp",
+		"-",
+	)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return false, fmt.Errorf("StdinPipe: %w", err)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false, fmt.Errorf("StdoutPipe: %w", err)
+	}
+
+	if err = cmd.Start(); err != nil {
+		return false, fmt.Errorf("failed to start ffmpeg: %w", err)
+	}
+
+	feedErr := make(chan error, 1)
+	go func() {
+		defer stdin.Close()
+		feedErr <- s.feed(ctx, stdin)
+		cancel()
+	}()
+
+	decoded, readErr := s.readFrames(stdout)
+	cancel()
+
+	waitErr := cmd.Wait()
+
+	if err = <-feedErr; err != nil && !errors.Is(err, context.Canceled) {
+		return decoded, fmt.Errorf("feed: %w", err)
+	}
+	if readErr != nil {
+		return decoded, fmt.Errorf("readFrames: %w", readErr)
+	}
+
+	return decoded, waitErr
+}
+
+// feed writes new live segments of the playlist into w until ctx is done or an error occurs
+func (s *Session) feed(ctx context.Context, w io.Writer) error {
+	var lastSequence int64 = -1
+
+	for {
+		playlist, err := s.client.fetchPlaylist(ctx, s.m3u8URL)
+		if err != nil {
+			return fmt.Errorf("fetchPlaylist: %w", err)
+		}
+
+		for _, segment := range playlist.Segments {
+			// start from the newest segment, older ones are already outdated
+			if lastSequence < 0 {
+				newest, ok := playlist.NewestSegment(s.client.cfg.Twitch.AdsCheck)
+				if !ok {
+					break
+				}
+				lastSequence = newest.Sequence - 1
+			}
+
+			if segment.Sequence <= lastSequence {
+				continue
+			}
+			lastSequence = segment.Sequence
+
+			if segment.Ad && s.client.cfg.Twitch.AdsCheck {
+				continue
+			}
+
+			data, err := s.client.fetch(ctx, segment.URL, maxSegmentSize)
+			if err != nil {
+				return fmt.Errorf("failed to fetch segment: %w", err)
+			}
+
+			if _, err = w.Write(data); err != nil {
+				return fmt.Errorf("failed to write segment: %w", err)
+			}
+		}
+
+		wait := time.Duration(playlist.TargetDuration * float64(time.Second) / 2)
+		if wait <= 0 {
+			wait = time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// readFrames reads consecutive BMP images from r, the size of each one is taken from its header
+func (s *Session) readFrames(r io.Reader) (bool, error) {
+	reader := bufio.NewReaderSize(r, 1024*1024)
+	decoded := false
+
+	for {
+		header, err := reader.Peek(bmpHeaderSize)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return decoded, nil
+			}
+
+			return decoded, fmt.Errorf("failed to read BMP header: %w", err)
+		}
+
+		if header[0] != 'B' || header[1] != 'M' {
+			return decoded, fmt.Errorf("invalid BMP signature")
+		}
+
+		size := binary.LittleEndian.Uint32(header[2:6])
+		if size <= bmpHeaderSize || size > maxSegmentSize {
+			return decoded, fmt.Errorf("invalid BMP size: %d", size)
+		}
+
+		data := make([]byte, size)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return decoded, fmt.Errorf("failed to read BMP data: %w", err)
+		}
+
+		frame, err := bmp.Decode(bytes.NewReader(data))
+		if err != nil {
+			return decoded, fmt.Errorf("invalid BMP data from ffmpeg: %w", err)
+		}
+
+		s.setFrame(frame)
+		decoded = true
+	}
+}
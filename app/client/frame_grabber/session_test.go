@@ -0,0 +1,50 @@
+package frame_grabber
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/bmp"
+)
+
+func TestSessionReadFrames(t *testing.T) {
+	var stream bytes.Buffer
+
+	for _, c := range []color.RGBA{{R: 255, A: 255}, {G: 255, A: 255}} {
+		img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+		for x := range 3 {
+			for y := range 2 {
+				img.Set(x, y, c)
+			}
+		}
+
+		require.NoError(t, bmp.Encode(&stream, img))
+	}
+
+	session := &Session{}
+
+	decoded, err := session.readFrames(&stream)
+	require.NoError(t, err)
+	assert.True(t, decoded)
+
+	frame, frameTime := session.LatestFrame()
+	require.NotNil(t, frame)
+	assert.False(t, frameTime.IsZero())
+	assert.Equal(t, image.Pt(3, 2), frame.Bounds().Size())
+
+	r, g, _, _ := frame.At(0, 0).RGBA()
+	assert.Zero(t, r)
+	assert.NotZero(t, g)
+}
+
+func TestSessionReadFramesInvalid(t *testing.T) {
+	session := &Session{}
+
+	decoded, err := session.readFrames(bytes.NewReader([]byte("not a bmp image")))
+	require.Error(t, err)
+	assert.False(t, decoded)
+}
@@ -0,0 +1,61 @@
+package ocr
+
+import (
+	"context"
+	"image"
+	"sync"
+)
+
+// Fixture is an in-memory engine for tests, it returns the given responses in order and repeats the last one
+type Fixture struct {
+	mu        sync.Mutex
+	responses []*Response
+	err       error
+	calls     int
+}
+
+func NewFixture(responses ...*Response) *Fixture {
+	return &Fixture{
+		responses: responses,
+	}
+}
+
+// NewFailingFixture returns an engine that fails every request with err
+func NewFailingFixture(err error) *Fixture {
+	return &Fixture{
+		err: err,
+	}
+}
+
+func (f *Fixture) Name() string {
+	return "fixture"
+}
+
+func (f *Fixture) Recognize(_ context.Context, _ image.Image) (*Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if len(f.responses) == 0 {
+		return &Response{}, nil
+	}
+
+	return f.responses[min(f.calls, len(f.responses))-1], nil
+}
+
+func (f *Fixture) HealthCheck(_ context.Context) error {
+	return f.err
+}
+
+// Calls returns the number of Recognize calls
+func (f *Fixture) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls
+}
@@ -0,0 +1,27 @@
+package ocr
+
+import (
+	"context"
+	"image"
+)
+
+// Response is the text recognized on the image, one result per text line
+type Response struct {
+	Results []Result
+}
+
+type Result struct {
+	Text string
+	// Confidence is in [0, 1]
+	Confidence float64
+	// Box is the bounding box of the text on the image, empty if the engine does not report it
+	Box image.Rectangle
+}
+
+// Engine recognizes text lines on images
+type Engine interface {
+	// Name identifies the engine in the config and logs
+	Name() string
+	Recognize(ctx context.Context, img image.Image) (*Response, error)
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,206 @@
+package paddle
+
+import (
+	"context"
+	"hyperfocus/app/client/ocr"
+	"image"
+	"image/draw"
+	"time"
+)
+
+type batchRequest struct {
+	ctx    context.Context
+	img    image.Image
+	result chan batchResult
+}
+
+type batchResult struct {
+	res *ocr.Response
+	err error
+}
+
+func (c *Client) recognizeBatched(ctx context.Context, img image.Image) (*ocr.Response, error) {
+	req := &batchRequest{
+		ctx:    ctx,
+		img:    img,
+		result: make(chan batchResult, 1),
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case c.batchChan <- req:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-req.result:
+		return res.res, res.err
+	}
+}
+
+// RunBatchLoop gathers concurrent Recognize calls into batches, a batch is sent once it is full
+// or the first image in it has waited for the configured time.
+// The sidecar only recognizes one image per request, so the images of the batch are stitched into one.
+func (c *Client) RunBatchLoop(ctx context.Context) {
+	if c.cfg.Paddle.BatchSize <= 1 {
+		return
+	}
+
+	c.batching.Store(true)
+	defer c.batching.Store(false)
+
+	wait := time.Duration(c.cfg.Paddle.BatchWait) * time.Millisecond
+
+	for {
+		var batch []*batchRequest
+
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-c.batchChan:
+			batch = append(batch, req)
+		}
+
+		timer := time.NewTimer(wait)
+
+	collect:
+		for len(batch) < c.cfg.Paddle.BatchSize {
+			select {
+			case <-ctx.Done():
+				break collect
+			case <-timer.C:
+				break collect
+			case req := <-c.batchChan:
+				batch = append(batch, req)
+			}
+		}
+
+		timer.Stop()
+
+		go c.sendBatch(ctx, batch)
+	}
+}
+
+func (c *Client) sendBatch(ctx context.Context, batch []*batchRequest) {
+	// requests that were cancelled while waiting are not sent
+	active := make([]*batchRequest, 0, len(batch))
+	for _, req := range batch {
+		if req.ctx.Err() == nil {
+			active = append(active, req)
+		}
+	}
+
+	for len(active) > 0 {
+		// the stitched image is limited in height, the rest of the batch goes in the next request
+		count, height := 0, 0
+		for count < len(active) {
+			next := height + active[count].img.Bounds().Dy()
+			if count > 0 {
+				next += stitchGap
+			}
+			if count > 0 && next > c.cfg.Paddle.BatchMaxHeight {
+				break
+			}
+
+			height = next
+			count++
+		}
+
+		images := make([]image.Image, 0, count)
+		for _, req := range active[:count] {
+			images = append(images, req.img)
+		}
+
+		started := time.Now()
+		results, err := c.recognizeStitched(ctx, images)
+		c.metrics.RecordOCRRequest(ctx, c.Name(), len(images), time.Since(started), err)
+
+		for i, req := range active[:count] {
+			if err != nil {
+				req.result <- batchResult{err: err}
+				continue
+			}
+
+			req.result <- batchResult{res: results[i]}
+		}
+
+		active = active[count:]
+	}
+}
+
+// recognizeStitched stacks the images into one and recognizes it with a single request,
+// results are in the same order as the images
+func (c *Client) recognizeStitched(ctx context.Context, images []image.Image) ([]*ocr.Response, error) {
+	stitched, areas := stitchImages(images)
+
+	res, err := c.recognizeSingle(ctx, stitched)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitResults(res, areas), nil
+}
+
+// stitchGap is the blank space between the stitched images, it keeps the text detector
+// from joining the lines of neighbour images
+const stitchGap = 32
+
+// stitchImages stacks the images top to bottom on a white background, areas are the positions of the images
+func stitchImages(images []image.Image) (image.Image, []image.Rectangle) {
+	var width, height int
+	for i, img := range images {
+		width = max(width, img.Bounds().Dx())
+		height += img.Bounds().Dy()
+		if i > 0 {
+			height += stitchGap
+		}
+	}
+
+	result := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(result, result.Bounds(), image.White, image.Point{}, draw.Src)
+
+	areas := make([]image.Rectangle, 0, len(images))
+
+	y := 0
+	for _, img := range images {
+		area := image.Rect(0, y, img.Bounds().Dx(), y+img.Bounds().Dy())
+		draw.Draw(result, area, img, img.Bounds().Min, draw.Src)
+
+		areas = append(areas, area)
+		y = area.Max.Y + stitchGap
+	}
+
+	return result, areas
+}
+
+// splitResults assigns every text of the stitched image to the image its box center is in,
+// boxes are moved to the coordinates of that image. Texts without boxes or in the gaps are dropped.
+func splitResults(res *ocr.Response, areas []image.Rectangle) []*ocr.Response {
+	result := make([]*ocr.Response, len(areas))
+	for i := range result {
+		result[i] = &ocr.Response{Results: []ocr.Result{}}
+	}
+
+	for _, text := range res.Results {
+		if text.Box.Empty() {
+			continue
+		}
+
+		center := image.Pt((text.Box.Min.X+text.Box.Max.X)/2, (text.Box.Min.Y+text.Box.Max.Y)/2)
+
+		for i, area := range areas {
+			if center.Y < area.Min.Y || center.Y >= area.Max.Y {
+				continue
+			}
+
+			text.Box = text.Box.Sub(area.Min).Intersect(image.Rect(0, 0, area.Dx(), area.Dy()))
+			result[i].Results = append(result[i].Results, text)
+
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,199 @@
+package paddle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hyperfocus/app/client/ocr"
+	"hyperfocus/app/config"
+	"hyperfocus/app/util/telemetry"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// recognizeBlocks answers with a text per dark block of the image: the text is the width of the block,
+// so that results can be matched with requests
+func recognizeBlocks(t *testing.T, fileHeader *multipart.FileHeader) ocrResponse {
+	file, err := fileHeader.Open()
+	require.NoError(t, err)
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	require.NoError(t, err)
+
+	dark := func(x, y int) bool {
+		return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y < 128 //nolint:forcetypeassert
+	}
+
+	var resp ocrResponse
+
+	top := -1
+	width := 0
+
+	// the row below the image ends the last block
+	for y := img.Bounds().Min.Y; y <= img.Bounds().Max.Y; y++ {
+		rowWidth := 0
+		if y < img.Bounds().Max.Y {
+			for x := img.Bounds().Min.X; x < img.Bounds().Max.X && dark(x, y); x++ {
+				rowWidth++
+			}
+		}
+
+		switch {
+		case rowWidth > 0 && top < 0:
+			top, width = y, rowWidth
+		case rowWidth == 0 && top >= 0:
+			resp.Results = append(resp.Results, ocrResult{
+				Text:       fmt.Sprint(width),
+				Confidence: 1,
+				Box: [][]float64{
+					{0, float64(top)}, {float64(width - 1), float64(top)},
+					{float64(width - 1), float64(y - 1)}, {0, float64(y - 1)},
+				},
+			})
+			top = -1
+		}
+	}
+
+	return resp
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{ //nolint:exhaustruct
+		Paddle: config.Paddle{
+			BaseURL:        server.URL,
+			BatchSize:      8,
+			BatchWait:      50,
+			BatchMaxHeight: 2048,
+		},
+	}
+
+	metrics, err := telemetry.NewMetrics(cfg, noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+
+	di := do.New()
+	do.ProvideValue(di, cfg)
+	do.ProvideValue(di, metrics)
+
+	client, err := NewClient(di)
+	require.NoError(t, err)
+
+	return client
+}
+
+func startBatchLoop(t *testing.T, client *Client) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go client.RunBatchLoop(ctx)
+	require.Eventually(t, client.batching.Load, time.Second, time.Millisecond)
+}
+
+// blockImage is a dark block of the given width on a white background
+func blockImage(width int) image.Image {
+	img := image.NewGray(image.Rect(0, 0, 100, 10))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, width, 10), image.Black, image.Point{}, draw.Src)
+
+	return img
+}
+
+func recognizeConcurrently(t *testing.T, client *Client, count int) {
+	var wg sync.WaitGroup
+
+	for i := 1; i <= count; i++ {
+		wg.Go(func() {
+			res, err := client.Recognize(context.Background(), blockImage(i))
+			if assert.NoError(t, err) && assert.Len(t, res.Results, 1) {
+				assert.Equal(t, fmt.Sprint(i), res.Results[0].Text)
+				assert.Equal(t, image.Rect(0, 0, i, 10), res.Results[0].Box)
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+func TestClient_RecognizeBatched(t *testing.T) {
+	var requests, images atomic.Int32
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ocr", r.URL.Path)
+
+		_, fileHeader, err := r.FormFile("file")
+		require.NoError(t, err)
+
+		resp := recognizeBlocks(t, fileHeader)
+
+		requests.Add(1)
+		images.Add(int32(len(resp.Results)))
+
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	startBatchLoop(t, client)
+
+	recognizeConcurrently(t, client, 20)
+
+	assert.Equal(t, int32(20), images.Load())
+	assert.Less(t, requests.Load(), int32(20))
+}
+
+func TestClient_RecognizeBatched_MaxHeight(t *testing.T) {
+	var requests atomic.Int32
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, fileHeader, err := r.FormFile("file")
+		require.NoError(t, err)
+
+		file, err := fileHeader.Open()
+		require.NoError(t, err)
+		defer file.Close()
+
+		header, err := png.DecodeConfig(file)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, header.Height, 3*10+2*stitchGap)
+
+		requests.Add(1)
+
+		require.NoError(t, json.NewEncoder(w).Encode(recognizeBlocks(t, fileHeader)))
+	}))
+	client.cfg.Paddle.BatchMaxHeight = 3*10 + 2*stitchGap
+	startBatchLoop(t, client)
+
+	recognizeConcurrently(t, client, 8)
+
+	assert.GreaterOrEqual(t, requests.Load(), int32(3))
+}
+
+func TestSplitResults(t *testing.T) {
+	_, areas := stitchImages([]image.Image{blockImage(10), blockImage(20)})
+	require.Equal(t, []image.Rectangle{image.Rect(0, 0, 100, 10), image.Rect(0, 10+stitchGap, 100, 20+stitchGap)}, areas)
+
+	results := splitResults(&ocr.Response{Results: []ocr.Result{
+		{Text: "first", Box: image.Rect(0, 1, 10, 9)},
+		{Text: "second", Box: image.Rect(5, 11+stitchGap, 20, 19+stitchGap)},
+		{Text: "gap", Box: image.Rect(0, 12, 10, 20)},
+		{Text: "no box"},
+	}}, areas)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, []ocr.Result{{Text: "first", Box: image.Rect(0, 1, 10, 9)}}, results[0].Results)
+	assert.Equal(t, []ocr.Result{{Text: "second", Box: image.Rect(5, 1, 20, 9)}}, results[1].Results)
+}
@@ -5,38 +5,80 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hyperfocus/app/client/ocr"
 	"hyperfocus/app/config"
+	"hyperfocus/app/util/telemetry"
 	"image"
 	"image/png"
+	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/samber/do"
 )
 
 type Client struct {
-	cfg    *config.Config
-	client *http.Client
+	cfg     *config.Config
+	metrics *telemetry.Metrics
+	client  *http.Client
+
+	batchChan chan *batchRequest
+	// batching is set while the batch loop runs, requests are sent one by one otherwise
+	batching atomic.Bool
+}
+
+type ocrResponse struct {
+	Results []ocrResult `json:"results"`
+	Error   string      `json:"error"`
+}
+
+type ocrResult struct {
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence"`
+	// Box is the detected text polygon, a list of [x, y] points
+	Box [][]float64 `json:"box"`
 }
 
-type OCRResponse struct {
-	Results []struct {
-		Text       string  `json:"text"`
-		Confidence float64 `json:"confidence"`
-	} `json:"results"`
-	Error string `json:"error"`
+// boundingBox returns the rectangle that contains the polygon
+func (r *ocrResult) boundingBox() image.Rectangle {
+	var result image.Rectangle
+
+	for i, point := range r.Box {
+		if len(point) < 2 {
+			continue
+		}
+
+		x, y := int(math.Floor(point[0])), int(math.Floor(point[1]))
+		pointRect := image.Rect(x, y, x+1, y+1)
+
+		if i == 0 {
+			result = pointRect
+		} else {
+			result = result.Union(pointRect)
+		}
+	}
+
+	return result
 }
 
 func NewClient(di *do.Injector) (*Client, error) {
 	return &Client{
-		cfg: do.MustInvoke[*config.Config](di),
+		cfg:     do.MustInvoke[*config.Config](di),
+		metrics: do.MustInvoke[*telemetry.Metrics](di),
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		batchChan: make(chan *batchRequest),
 	}, nil
 }
 
+func (c *Client) Name() string {
+	return "paddle"
+}
+
 func (c *Client) HealthCheck(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", c.cfg.Paddle.BaseURL+"/health", nil)
 	if err != nil {
@@ -56,48 +98,97 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-func (c *Client) Recognize(ctx context.Context, img image.Image) (*OCRResponse, error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", "image.png")
+// Recognize joins the batch if the batch loop is running, otherwise the image is sent on its own
+func (c *Client) Recognize(ctx context.Context, img image.Image) (*ocr.Response, error) {
+	if c.batching.Load() {
+		return c.recognizeBatched(ctx, img)
+	}
+
+	started := time.Now()
+	res, err := c.recognizeSingle(ctx, img)
+	c.metrics.RecordOCRRequest(ctx, c.Name(), 1, time.Since(started), err)
+
+	return res, err
+}
+
+func (c *Client) recognizeSingle(ctx context.Context, img image.Image) (*ocr.Response, error) {
+	body, contentType, err := encodeImages("file", img)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, err
 	}
 
-	if err = png.Encode(part, img); err != nil {
-		return nil, fmt.Errorf("failed to encode image: %w", err)
+	var ocrResp ocrResponse
+	if err = c.post(ctx, "/ocr", body, contentType, &ocrResp); err != nil {
+		return nil, err
 	}
 
-	if err = writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close writer: %w", err)
+	return ocrResp.toResponse()
+}
+
+// encodeImages writes the images as PNG files of the multipart form
+func encodeImages(field string, images ...image.Image) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for i, img := range images {
+		part, err := writer.CreateFormFile(field, fmt.Sprintf("image%d.png", i))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create form file: %w", err)
+		}
+
+		if err = png.Encode(part, img); err != nil {
+			return nil, "", fmt.Errorf("failed to encode image: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to close writer: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.cfg.Paddle.BaseURL+"/ocr", body)
+	return body, writer.FormDataContentType(), nil
+}
+
+func (c *Client) post(ctx context.Context, path string, body io.Reader, contentType string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.cfg.Paddle.BaseURL+path, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Content-Type", contentType)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("OCR request failed with status: %d", resp.StatusCode)
+		return fmt.Errorf("OCR request failed with status: %d", resp.StatusCode)
 	}
 
-	var ocrResp OCRResponse
-	err = json.NewDecoder(resp.Body).Decode(&ocrResp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode OCR response: %w", err)
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode OCR response: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ocrResponse) toResponse() (*ocr.Response, error) {
+	if r.Error != "" {
+		return nil, fmt.Errorf("OCR error: %s", r.Error)
+	}
+
+	result := &ocr.Response{
+		Results: make([]ocr.Result, 0, len(r.Results)),
 	}
 
-	if ocrResp.Error != "" {
-		return nil, fmt.Errorf("OCR error: %s", ocrResp.Error)
+	for _, res := range r.Results {
+		result.Results = append(result.Results, ocr.Result{
+			Text:       res.Text,
+			Confidence: res.Confidence,
+			Box:        res.boundingBox(),
+		})
 	}
 
-	return &ocrResp, nil
+	return result, nil
 }
@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hyperfocus/app/config"
+	"net/http"
+	"time"
+
+	"github.com/samber/do"
+)
+
+var ErrNotConfigured = errors.New("telegram bot token is not configured")
+
+type Client struct {
+	cfg    *config.Config
+	client *http.Client
+}
+
+func NewClient(di *do.Injector) (*Client, error) {
+	return &Client{
+		cfg: do.MustInvoke[*config.Config](di),
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+func (c *Client) SendMessage(ctx context.Context, chatID, text string) error {
+	if c.cfg.Alert.TelegramToken == "" {
+		return ErrNotConfigured
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"chat_id": chatID,
+		"text":    text,
+	})
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	url := "https://api.telegram.org/bot" + c.cfg.Alert.TelegramToken + "/sendMessage"
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tgResp struct {
+		Ok          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&tgResp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !tgResp.Ok {
+		return fmt.Errorf("telegram returned status %d: %s", resp.StatusCode, tgResp.Description)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package tesseract
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"hyperfocus/app/client/ocr"
+	"hyperfocus/app/config"
+	"image"
+	"image/png"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/do"
+)
+
+// Client runs the tesseract CLI, it is less accurate than PaddleOCR, but does not need a sidecar
+type Client struct {
+	cfg *config.Config
+}
+
+func NewClient(di *do.Injector) (*Client, error) {
+	return &Client{
+		cfg: do.MustInvoke[*config.Config](di),
+	}, nil
+}
+
+func (c *Client) Name() string {
+	return "tesseract"
+}
+
+func (c *Client) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.cfg.Tesseract.Path, "--version")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("tesseract is not available: %w, output: %s", err, output)
+	}
+
+	return nil
+}
+
+func (c *Client) Recognize(ctx context.Context, img image.Image) (*ocr.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var input bytes.Buffer
+	if err := png.Encode(&input, img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	// psm 6 treats the image as a single block of text, which fits the cropped name lists
+	cmd := exec.CommandContext(ctx, c.cfg.Tesseract.Path,
+		"stdin", "stdout",
+		"-l", c.cfg.Tesseract.Language,
+		"--psm", "6",
+		"tsv",
+	)
+
+	cmd.Stdin = &input
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("tesseract execution failed: %w, stderr: %s", err, stderr.String())
+	}
+
+	return parseTSV(stdout.String())
+}
+
+type lineKey struct {
+	block, paragraph, line int
+}
+
+type lineWords struct {
+	words      []string
+	confidence float64
+	box        image.Rectangle
+}
+
+// parseTSV joins the words of the tesseract TSV output into lines,
+// the confidence of the line is the mean confidence of its words
+func parseTSV(content string) (*ocr.Response, error) {
+	var order []lineKey
+	lines := make(map[lineKey]*lineWords)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	header := true
+
+	for scanner.Scan() {
+		if header {
+			header = false
+			continue
+		}
+
+		// level page_num block_num par_num line_num word_num left top width height conf text
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 12 {
+			continue
+		}
+
+		text := strings.TrimSpace(fields[11])
+		if text == "" {
+			continue
+		}
+
+		confidence, err := strconv.ParseFloat(fields[10], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid confidence %q: %w", fields[10], err)
+		}
+		if confidence < 0 {
+			continue
+		}
+
+		var key lineKey
+		if key.block, err = strconv.Atoi(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid block number %q: %w", fields[2], err)
+		}
+		if key.paragraph, err = strconv.Atoi(fields[3]); err != nil {
+			return nil, fmt.Errorf("invalid paragraph number %q: %w", fields[3], err)
+		}
+		if key.line, err = strconv.Atoi(fields[4]); err != nil {
+			return nil, fmt.Errorf("invalid line number %q: %w", fields[4], err)
+		}
+
+		var left, top, width, height int
+		for i, dst := range []*int{&left, &top, &width, &height} {
+			if *dst, err = strconv.Atoi(fields[6+i]); err != nil {
+				return nil, fmt.Errorf("invalid word box %q: %w", fields[6+i], err)
+			}
+		}
+		wordBox := image.Rect(left, top, left+width, top+height)
+
+		line, ok := lines[key]
+		if !ok {
+			line = &lineWords{}
+			lines[key] = line
+			order = append(order, key)
+		}
+
+		if len(line.words) == 0 {
+			line.box = wordBox
+		} else {
+			line.box = line.box.Union(wordBox)
+		}
+
+		line.words = append(line.words, text)
+		line.confidence += confidence / 100
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tesseract output: %w", err)
+	}
+
+	result := &ocr.Response{
+		Results: make([]ocr.Result, 0, len(order)),
+	}
+
+	for _, key := range order {
+		line := lines[key]
+
+		result.Results = append(result.Results, ocr.Result{
+			Text:       strings.Join(line.words, " "),
+			Confidence: line.confidence / float64(len(line.words)),
+			Box:        line.box,
+		})
+	}
+
+	return result, nil
+}
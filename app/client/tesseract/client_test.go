@@ -0,0 +1,35 @@
+package tesseract
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTSV(t *testing.T) {
+	content := "level\tpage_num\tblock_num\tpar_num\tline_num\tword_num\tleft\ttop\twidth\theight\tconf\ttext\n" +
+		"1\t1\t0\t0\t0\t0\t0\t0\t233\t415\t-1\t\n" +
+		"4\t1\t1\t1\t1\t0\t10\t12\t120\t20\t-1\t\n" +
+		"5\t1\t1\t1\t1\t1\t10\t12\t60\t20\t96.5\tSpooky\n" +
+		"5\t1\t1\t1\t1\t2\t75\t12\t55\t20\t89.5\tScary\n" +
+		"5\t1\t1\t1\t2\t1\t10\t95\t90\t20\t91\tClappnz\n" +
+		"5\t1\t1\t1\t3\t1\t10\t180\t5\t20\t0\t \n"
+
+	res, err := parseTSV(content)
+	require.NoError(t, err)
+	require.Len(t, res.Results, 2)
+
+	assert.Equal(t, "Spooky Scary", res.Results[0].Text)
+	assert.InDelta(t, 0.93, res.Results[0].Confidence, 0.001)
+	assert.Equal(t, image.Rect(10, 12, 130, 32), res.Results[0].Box)
+	assert.Equal(t, "Clappnz", res.Results[1].Text)
+	assert.InDelta(t, 0.91, res.Results[1].Confidence, 0.001)
+}
+
+func TestParseTSV_Empty(t *testing.T) {
+	res, err := parseTSV("")
+	require.NoError(t, err)
+	assert.Empty(t, res.Results)
+}
@@ -139,7 +139,7 @@ func (c *Client) getAccessToken(ctx context.Context, id string) (*AccessToken, e
 	}
 
 	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("json.Unmarshal", resp.StatusCode)
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
 	}
 
 	return response.Data.StreamPlaybackAccessToken, nil
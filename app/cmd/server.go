@@ -8,17 +8,23 @@ import (
 	"hyperfocus/app/api/middleware"
 	"hyperfocus/app/api/routes"
 	"hyperfocus/app/client/frame_grabber"
-	"hyperfocus/app/client/magick"
 	"hyperfocus/app/client/paddle"
+	"hyperfocus/app/client/telegram"
+	"hyperfocus/app/client/tesseract"
 	twitchC "hyperfocus/app/client/twitch"
 	"hyperfocus/app/client/twitch_live"
+	"hyperfocus/app/client/webhook"
 	"hyperfocus/app/config"
 	"hyperfocus/app/database"
 	"hyperfocus/app/database/migration"
 	"hyperfocus/app/service/alert"
+	"hyperfocus/app/service/alias"
 	"hyperfocus/app/service/analyze"
+	"hyperfocus/app/service/auth"
+	"hyperfocus/app/service/feed"
 	"hyperfocus/app/service/limits"
 	"hyperfocus/app/service/search"
+	"hyperfocus/app/service/stream"
 	"hyperfocus/app/service/twitch"
 	"hyperfocus/app/util/dbd"
 	"hyperfocus/app/util/mylog"
@@ -165,27 +171,34 @@ func runServer(_ *cobra.Command, _ []string) {
 
 	do.Provide(di, twitchC.NewClient)
 	do.Provide(di, twitch_live.NewClient)
+	do.Provide(di, telegram.NewClient)
+	do.Provide(di, webhook.NewClient)
 	do.Provide(di, paddle.NewClient)
+	do.Provide(di, tesseract.NewClient)
 	do.Provide(di, frame_grabber.NewClient)
-	do.Provide(di, magick.NewClient)
 	do.Provide(di, dbd.NewImageAnalyzer)
 
 	do.Provide(di, limits.New)
+	do.Provide(di, auth.New)
 	do.Provide(di, twitch.New)
+	do.Provide(di, alias.New)
 	do.Provide(di, analyze.New)
 	do.Provide(di, search.New)
+	do.Provide(di, feed.New)
+	do.Provide(di, stream.New)
 	do.Provide(di, alert.New)
 
-	if err = do.MustInvoke[*paddle.Client](di).HealthCheck(appCtx); err != nil {
-		slog.Error("PaddleOCR client init failed",
+	// OCR outages degrade the analysis instead of stopping the whole pipeline
+	if err = do.MustInvoke[*dbd.ImageAnalyzer](di).HealthCheck(appCtx); err != nil {
+		slog.Error("OCR engines are unavailable",
 			slog.Any("error", err),
 		)
-		os.Exit(1)
-		return
 	}
 
 	go do.MustInvoke[*twitchC.Client](di).RunRefreshLoop(appCtx)
+	go do.MustInvoke[*paddle.Client](di).RunBatchLoop(appCtx)
 	go do.MustInvoke[*twitch.Service](di).RunFetchLoop(appCtx)
+	go do.MustInvoke[*frame_grabber.Client](di).RunSessionEvictionLoop(appCtx)
 	go do.MustInvoke[*analyze.Service](di).RunProcessLoop(appCtx)
 	go do.MustInvoke[*alert.Service](di).RunFetchLoop(appCtx)
 
@@ -206,6 +219,7 @@ func runServer(_ *cobra.Command, _ []string) {
 	routes.StaticRoutes(app)
 
 	apiGroup := app.Group("/v1")
+	routes.FeedRoutes(apiGroup, di)
 	api.RegisterHandlersWithOptions(apiGroup, handler, api.FiberServerOptions{
 		BaseURL: "",
 		Middlewares: []api.MiddlewareFunc{
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"hyperfocus/app/config"
+	"hyperfocus/app/service/auth"
+	"log/slog"
+	"os"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+)
+
+var tokenUsername string
+var tokenRoles []string
+
+var Token = &cobra.Command{
+	Use:   "token",
+	Short: "Issue API access token",
+	Run:   runToken,
+}
+
+func init() {
+	Token.Flags().StringVarP(&configPath, "config", "c", "config.yaml", "Path to config yaml file (required)")
+	Token.Flags().StringVarP(&tokenUsername, "username", "u", "", "Token owner username (required)")
+	Token.Flags().StringSliceVarP(&tokenRoles, "role", "r", []string{auth.RoleAdmin}, "Token roles")
+	_ = Token.MarkFlagRequired("username")
+}
+
+func runToken(_ *cobra.Command, _ []string) {
+	di := do.New()
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		slog.Error("Failed to load config",
+			slog.Any("error", err),
+		)
+		os.Exit(1)
+		return
+	}
+	do.ProvideValue(di, cfg)
+	do.Provide(di, auth.New)
+
+	token, err := do.MustInvoke[*auth.Service](di).IssueToken(tokenUsername, tokenRoles)
+	if err != nil {
+		slog.Error("Failed to issue token",
+			slog.Any("error", err),
+		)
+		os.Exit(1)
+		return
+	}
+
+	fmt.Println(token) //nolint:forbidigo
+}
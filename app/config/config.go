@@ -19,11 +19,16 @@ type Config struct {
 	Telemetry  Telemetry  `yaml:"telemetry" envPrefix:"TELEMETRY_"`
 	DB         DB         `yaml:"db" envPrefix:"DB_"`
 	Twitch     Twitch     `yaml:"twitch" envPrefix:"TWITCH_"`
+	OCR        OCR        `yaml:"ocr" envPrefix:"OCR_"`
 	Paddle     Paddle     `yaml:"paddle" envPrefix:"PADDLE_"`
+	Tesseract  Tesseract  `yaml:"tesseract" envPrefix:"TESSERACT_"`
 	Processing Processing `yaml:"processing" envPrefix:"PROCESSING_"`
+	Search     Search     `yaml:"search" envPrefix:"SEARCH_"`
 	Alert      Alert      `yaml:"alert" envPrefix:"ALERT_"`
+	Feed       Feed       `yaml:"feed" envPrefix:"FEED_"`
 	Proxy      Proxy      `yaml:"proxy" envPrefix:"PROXY_"`
 	Server     Server     `yaml:"server" envPrefix:"SERVER_"`
+	Auth       Auth       `yaml:"auth" envPrefix:"AUTH_"`
 }
 
 type Sentry struct {
@@ -73,9 +78,28 @@ type Twitch struct {
 	AdsCheck bool `yaml:"ads_check" example:"false"`
 }
 
+type OCR struct {
+	// OCR engines in the fallback order, the next one is used while the previous one fails
+	Engines []string `yaml:"engines" env:"ENGINES" example:"paddle,tesseract" validate:"dive,oneof=paddle tesseract"`
+}
+
 type Paddle struct {
 	// PaddleOCR service base URL
 	BaseURL string `yaml:"base_url" example:"http://localhost:5000" validate:"required"`
+	// Max number of images sent in one request, batching is disabled if 0 or 1
+	BatchSize int `yaml:"batch_size" env:"BATCH_SIZE" example:"16"`
+	// Max time in milliseconds an image waits for the batch to fill up
+	BatchWait int `yaml:"batch_wait" env:"BATCH_WAIT" example:"50"`
+	// Max height in pixels of the image the batch is stitched into, the rest of the batch is sent separately.
+	// The text detector downscales larger images, which makes the names unreadable.
+	BatchMaxHeight int `yaml:"batch_max_height" env:"BATCH_MAX_HEIGHT" example:"2048"`
+}
+
+type Tesseract struct {
+	// Path to the tesseract executable
+	Path string `yaml:"path" env:"PATH" example:"tesseract"`
+	// Tesseract language
+	Language string `yaml:"language" env:"LANGUAGE" example:"eng"`
 }
 
 type Processing struct {
@@ -89,6 +113,19 @@ type Processing struct {
 	ProcessWorkerCount int `yaml:"process_worker_count" example:"8" validate:"required"`
 	// Channel processing timeout in seconds
 	ProcessTimeout int `yaml:"process_timeout" example:"60" validate:"required"`
+	// Number of the latest analyses kept per stream for the stream timeline, the API returns at most 100 of them
+	TimelineSize int `yaml:"timeline_size" example:"50" validate:"gte=0,lte=100"`
+	// Long-lived frame sessions for alert-relevant streams
+	Sessions FrameSessions `yaml:"sessions" envPrefix:"SESSIONS_"`
+}
+
+type FrameSessions struct {
+	// Max number of concurrent sessions, sessions are disabled if 0
+	MaxCount int `yaml:"max_count" env:"MAX_COUNT" example:"16"`
+	// Interval between decoded keyframes in seconds
+	FrameInterval int `yaml:"frame_interval" env:"FRAME_INTERVAL" example:"5"`
+	// Session is closed if its frames were not read for this many seconds
+	IdleTimeout int `yaml:"idle_timeout" env:"IDLE_TIMEOUT" example:"300"`
 }
 
 type Server struct {
@@ -96,6 +133,26 @@ type Server struct {
 	HttpPort int `yaml:"http_port" env:"HTTP_PORT" example:"8080" validate:"required"`
 }
 
+type Auth struct {
+	// Secret used to sign API access tokens, authenticated endpoints are disabled if empty
+	JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET" example:"change-me"`
+	// Access token TTL in hours
+	TokenTTL int `yaml:"token_ttl" env:"TOKEN_TTL" example:"8760"`
+}
+
+type Search struct {
+	// Weighted edit distance below which a nickname matches the query, 0 matches only exact and substring nicknames
+	MaxDistance float64 `yaml:"max_distance" env:"MAX_DISTANCE" example:"3" validate:"gte=0"`
+	// Trigram similarity in [0, 1] that database candidates need to have, lower values find more distorted nicknames but are slower.
+	// 0 disables the trigram prefilter and compares the query with every nickname
+	MinSimilarity float64 `yaml:"min_similarity" env:"MIN_SIMILARITY" example:"0.3" validate:"gte=0,lte=1"`
+	// Max number of results of a single search
+	MaxResults int `yaml:"max_results" env:"MAX_RESULTS" example:"20" validate:"gte=0"`
+	// Match score in [0, 1] that a nickname needs to fire an alert, the default 0.5 ignores substring matches.
+	// 0 alerts on every match
+	MinAlertScore float64 `yaml:"min_alert_score" env:"MIN_ALERT_SCORE" example:"0.5" validate:"gte=0,lte=1"`
+}
+
 type AlertEntry struct {
 	Streamer string   `yaml:"streamer" example:"k0per1s"`
 	Queries  []string `yaml:"queries" example:"k0per1s,k0peris"`
@@ -108,17 +165,35 @@ type Alert struct {
 	CheckInterval int `yaml:"check_interval" example:"10"`
 	// Alert TTL in seconds
 	TTL int `yaml:"ttl" example:"60"`
-	// List of alerts
+	// Telegram bot token used by telegram alert channels
+	TelegramToken string `yaml:"telegram_token" env:"TELEGRAM_TOKEN" example:"1234567890:ABCdefGHIjklMNopQRstUVwxyZ-123456789"`
+	// List of alerts, imported into the database on first start and managed via API afterwards
 	List []AlertEntry `yaml:"list" env:"LIST"`
 }
 
+type Feed struct {
+	// Max number of events queued for a live feed client, newer events are dropped while the client is behind
+	BufferSize int `yaml:"buffer_size" env:"BUFFER_SIZE" example:"64" validate:"gte=0"`
+	// Interval between heartbeat pings in seconds, the client is disconnected if it does not answer within two intervals
+	PingInterval int `yaml:"ping_interval" env:"PING_INTERVAL" example:"30" validate:"gte=0"`
+	// Max number of topics a single client can subscribe to
+	MaxTopics int `yaml:"max_topics" env:"MAX_TOPICS" example:"32" validate:"gte=0"`
+}
+
 type Proxy struct {
 	// List of proxies
 	List []string `yaml:"list" env:"LIST"`
 }
 
 func Load(configPath string) (*Config, error) {
-	var result Config
+	// defaults of the settings where zero is a meaningful value, they are overwritten only if set explicitly
+	result := Config{ //nolint:exhaustruct
+		Search: Search{ //nolint:exhaustruct
+			MaxDistance:   3,
+			MinSimilarity: 0.3,
+			MinAlertScore: 0.5,
+		},
+	}
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -167,18 +242,57 @@ func Load(configPath string) (*Config, error) {
 	if result.Processing.FrameBufferSize == 0 {
 		result.Processing.FrameBufferSize = 256
 	}
+	if result.Processing.TimelineSize == 0 {
+		result.Processing.TimelineSize = 50
+	}
+	if result.Processing.Sessions.FrameInterval == 0 {
+		result.Processing.Sessions.FrameInterval = 5
+	}
+	if result.Processing.Sessions.IdleTimeout == 0 {
+		result.Processing.Sessions.IdleTimeout = 300
+	}
 	if result.Server.HttpPort == 0 {
 		result.Server.HttpPort = 8080
 	}
 	if result.Paddle.BaseURL == "" {
 		result.Paddle.BaseURL = "http://localhost:5000"
 	}
+	if result.Paddle.BatchWait == 0 {
+		result.Paddle.BatchWait = 50
+	}
+	if result.Paddle.BatchMaxHeight == 0 {
+		result.Paddle.BatchMaxHeight = 2048
+	}
+	if len(result.OCR.Engines) == 0 {
+		result.OCR.Engines = []string{"paddle"}
+	}
+	if result.Tesseract.Path == "" {
+		result.Tesseract.Path = "tesseract"
+	}
+	if result.Tesseract.Language == "" {
+		result.Tesseract.Language = "eng"
+	}
+	if result.Search.MaxResults == 0 {
+		result.Search.MaxResults = 20
+	}
 	if result.Alert.CheckInterval == 0 {
 		result.Alert.CheckInterval = 10
 	}
 	if result.Alert.TTL == 0 {
 		result.Alert.TTL = 60
 	}
+	if result.Feed.BufferSize == 0 {
+		result.Feed.BufferSize = 64
+	}
+	if result.Feed.PingInterval == 0 {
+		result.Feed.PingInterval = 30
+	}
+	if result.Feed.MaxTopics == 0 {
+		result.Feed.MaxTopics = 32
+	}
+	if result.Auth.TokenTTL == 0 {
+		result.Auth.TokenTTL = 24 * 365
+	}
 
 	validate := validator.New(validator.WithRequiredStructEnabled())
 	if err := validate.Struct(result); err != nil {
@@ -6,3 +6,42 @@ import (
 
 //go:embed schema.sql
 var Schema string
+
+//go:embed schema/0002_sightings.sql
+var SchemaSightings string
+
+//go:embed schema/0003_alert_subscriptions.sql
+var SchemaAlertSubscriptions string
+
+//go:embed schema/0004_alert_channels.sql
+var SchemaAlertChannels string
+
+//go:embed schema/0005_alert_confirmation.sql
+var SchemaAlertConfirmation string
+
+//go:embed schema/0006_alert_mutual.sql
+var SchemaAlertMutual string
+
+//go:embed schema/0007_streamer_aliases.sql
+var SchemaStreamerAliases string
+
+//go:embed schema/0008_scan_schedule.sql
+var SchemaScanSchedule string
+
+//go:embed schema/0009_stream_phase.sql
+var SchemaStreamPhase string
+
+//go:embed schema/0010_nickname_details.sql
+var SchemaNicknameDetails string
+
+//go:embed schema/0011_nickname_confusables.sql
+var SchemaNicknameConfusables string
+
+//go:embed schema/0012_nickname_trigrams.sql
+var SchemaNicknameTrigrams string
+
+//go:embed schema/0013_stream_metadata.sql
+var SchemaStreamMetadata string
+
+//go:embed schema/0014_stream_analyses.sql
+var SchemaStreamAnalyses string
@@ -22,6 +22,19 @@ type Migration interface {
 
 var allMigrations = []Migration{
 	&v0001InitSchema{},
+	&v0002Sightings{},
+	&v0003AlertSubscriptions{},
+	&v0004AlertChannels{},
+	&v0005AlertConfirmation{},
+	&v0006AlertMutual{},
+	&v0007StreamerAliases{},
+	&v0008ScanSchedule{},
+	&v0009StreamPhase{},
+	&v0010NicknameDetails{},
+	&v0011NicknameConfusables{},
+	&v0012NicknameTrigrams{},
+	&v0013StreamMetadata{},
+	&v0014StreamAnalyses{},
 }
 
 func doExecute(
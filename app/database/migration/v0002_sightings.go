@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0002Sightings)(nil)
+
+type v0002Sightings struct{}
+
+func (v *v0002Sightings) Name() string {
+	return "v0002_sightings"
+}
+
+func (v *v0002Sightings) Version() int32 {
+	return 2
+}
+
+func (v *v0002Sightings) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Creating sightings table...")
+
+	_, err := tx.Exec(ctx, database.SchemaSightings)
+	if err != nil {
+		return oops.Errorf("failed to create sightings table: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Sightings table successfully created")
+
+	return nil
+}
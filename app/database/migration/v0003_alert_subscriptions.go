@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/config"
+	"hyperfocus/app/database"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0003AlertSubscriptions)(nil)
+
+type v0003AlertSubscriptions struct{}
+
+func (v *v0003AlertSubscriptions) Name() string {
+	return "v0003_alert_subscriptions"
+}
+
+func (v *v0003AlertSubscriptions) Version() int32 {
+	return 3
+}
+
+func (v *v0003AlertSubscriptions) Execute(ctx context.Context, slogger *slog.Logger, di *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Creating alert subscription tables...")
+
+	_, err := tx.Exec(ctx, database.SchemaAlertSubscriptions)
+	if err != nil {
+		return oops.Errorf("failed to create alert subscription tables: %w", err)
+	}
+
+	// import alerts that used to live in the config file,
+	// raw sql is used since generated queries follow the latest schema
+	cfg := do.MustInvoke[*config.Config](di)
+
+	for _, entry := range cfg.Alert.List {
+		var subscriptionID int64
+
+		// entries of the same streamer, also differing only in case, are merged into one subscription
+		if err = tx.QueryRow(ctx,
+			"INSERT INTO alert_subscriptions(streamer) VALUES ($1) "+
+				"ON CONFLICT (streamer) DO UPDATE SET streamer = EXCLUDED.streamer RETURNING id",
+			strings.ToLower(entry.Streamer),
+		).Scan(&subscriptionID); err != nil {
+			return oops.Errorf("failed to insert alert subscription: %w", err)
+		}
+
+		for _, query := range entry.Queries {
+			if _, err = tx.Exec(ctx,
+				"INSERT INTO alert_queries(subscription_id, query) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+				subscriptionID, query,
+			); err != nil {
+				return oops.Errorf("failed to insert alert query: %w", err)
+			}
+		}
+	}
+
+	slogger.InfoContext(ctx, "Alert subscription tables successfully created",
+		slog.Int("imported_count", len(cfg.Alert.List)),
+	)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0004AlertChannels)(nil)
+
+type v0004AlertChannels struct{}
+
+func (v *v0004AlertChannels) Name() string {
+	return "v0004_alert_channels"
+}
+
+func (v *v0004AlertChannels) Version() int32 {
+	return 4
+}
+
+func (v *v0004AlertChannels) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Creating alert channel tables...")
+
+	_, err := tx.Exec(ctx, database.SchemaAlertChannels)
+	if err != nil {
+		return oops.Errorf("failed to create alert channel tables: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Alert channel tables successfully created")
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0005AlertConfirmation)(nil)
+
+type v0005AlertConfirmation struct{}
+
+func (v *v0005AlertConfirmation) Name() string {
+	return "v0005_alert_confirmation"
+}
+
+func (v *v0005AlertConfirmation) Version() int32 {
+	return 5
+}
+
+func (v *v0005AlertConfirmation) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Adding alert confirmation state...")
+
+	_, err := tx.Exec(ctx, database.SchemaAlertConfirmation)
+	if err != nil {
+		return oops.Errorf("failed to add alert confirmation state: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Alert confirmation state successfully added")
+
+	return nil
+}
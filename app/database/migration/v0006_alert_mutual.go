@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0006AlertMutual)(nil)
+
+type v0006AlertMutual struct{}
+
+func (v *v0006AlertMutual) Name() string {
+	return "v0006_alert_mutual"
+}
+
+func (v *v0006AlertMutual) Version() int32 {
+	return 6
+}
+
+func (v *v0006AlertMutual) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Adding mutual alert matching...")
+
+	_, err := tx.Exec(ctx, database.SchemaAlertMutual)
+	if err != nil {
+		return oops.Errorf("failed to add mutual alert matching: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Mutual alert matching successfully added")
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0007StreamerAliases)(nil)
+
+type v0007StreamerAliases struct{}
+
+func (v *v0007StreamerAliases) Name() string {
+	return "v0007_streamer_aliases"
+}
+
+func (v *v0007StreamerAliases) Version() int32 {
+	return 7
+}
+
+func (v *v0007StreamerAliases) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Creating streamer aliases table...")
+
+	_, err := tx.Exec(ctx, database.SchemaStreamerAliases)
+	if err != nil {
+		return oops.Errorf("failed to create streamer aliases table: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Streamer aliases table successfully created")
+
+	return nil
+}
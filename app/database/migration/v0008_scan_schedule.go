@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0008ScanSchedule)(nil)
+
+type v0008ScanSchedule struct{}
+
+func (v *v0008ScanSchedule) Name() string {
+	return "v0008_scan_schedule"
+}
+
+func (v *v0008ScanSchedule) Version() int32 {
+	return 8
+}
+
+func (v *v0008ScanSchedule) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Adding stream scan schedule...")
+
+	_, err := tx.Exec(ctx, database.SchemaScanSchedule)
+	if err != nil {
+		return oops.Errorf("failed to add stream scan schedule: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Stream scan schedule successfully added")
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0009StreamPhase)(nil)
+
+type v0009StreamPhase struct{}
+
+func (v *v0009StreamPhase) Name() string {
+	return "v0009_stream_phase"
+}
+
+func (v *v0009StreamPhase) Version() int32 {
+	return 9
+}
+
+func (v *v0009StreamPhase) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Adding stream game phase...")
+
+	_, err := tx.Exec(ctx, database.SchemaStreamPhase)
+	if err != nil {
+		return oops.Errorf("failed to add stream game phase: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Stream game phase successfully added")
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0010NicknameDetails)(nil)
+
+type v0010NicknameDetails struct{}
+
+func (v *v0010NicknameDetails) Name() string {
+	return "v0010_nickname_details"
+}
+
+func (v *v0010NicknameDetails) Version() int32 {
+	return 10
+}
+
+func (v *v0010NicknameDetails) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Adding nickname confidence, slots and boxes...")
+
+	_, err := tx.Exec(ctx, database.SchemaNicknameDetails)
+	if err != nil {
+		return oops.Errorf("failed to add nickname details: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Nickname details successfully added")
+
+	return nil
+}
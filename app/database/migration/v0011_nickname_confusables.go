@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0011NicknameConfusables)(nil)
+
+type v0011NicknameConfusables struct{}
+
+func (v *v0011NicknameConfusables) Name() string {
+	return "v0011_nickname_confusables"
+}
+
+func (v *v0011NicknameConfusables) Version() int32 {
+	return 11
+}
+
+func (v *v0011NicknameConfusables) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Normalizing OCR confusables in nicknames...")
+
+	_, err := tx.Exec(ctx, database.SchemaNicknameConfusables)
+	if err != nil {
+		return oops.Errorf("failed to normalize nicknames: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Nicknames successfully normalized")
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0012NicknameTrigrams)(nil)
+
+type v0012NicknameTrigrams struct{}
+
+func (v *v0012NicknameTrigrams) Name() string {
+	return "v0012_nickname_trigrams"
+}
+
+func (v *v0012NicknameTrigrams) Version() int32 {
+	return 12
+}
+
+func (v *v0012NicknameTrigrams) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Indexing nicknames by trigrams...")
+
+	_, err := tx.Exec(ctx, database.SchemaNicknameTrigrams)
+	if err != nil {
+		return oops.Errorf("failed to index nicknames: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Nicknames successfully indexed")
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0013StreamMetadata)(nil)
+
+type v0013StreamMetadata struct{}
+
+func (v *v0013StreamMetadata) Name() string {
+	return "v0013_stream_metadata"
+}
+
+func (v *v0013StreamMetadata) Version() int32 {
+	return 13
+}
+
+func (v *v0013StreamMetadata) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Adding stream language and viewer count...")
+
+	_, err := tx.Exec(ctx, database.SchemaStreamMetadata)
+	if err != nil {
+		return oops.Errorf("failed to add stream metadata: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Stream metadata successfully added")
+
+	return nil
+}
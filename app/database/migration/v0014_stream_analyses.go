@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var _ Migration = (*v0014StreamAnalyses)(nil)
+
+type v0014StreamAnalyses struct{}
+
+func (v *v0014StreamAnalyses) Name() string {
+	return "v0014_stream_analyses"
+}
+
+func (v *v0014StreamAnalyses) Version() int32 {
+	return 14
+}
+
+func (v *v0014StreamAnalyses) Execute(ctx context.Context, slogger *slog.Logger, _ *do.Injector, tx pgx.Tx, _ database.TxQueries) error {
+	slogger.InfoContext(ctx, "Creating stream analyses table...")
+
+	_, err := tx.Exec(ctx, database.SchemaStreamAnalyses)
+	if err != nil {
+		return oops.Errorf("failed to create stream analyses table: %w", err)
+	}
+
+	slogger.InfoContext(ctx, "Stream analyses table successfully created")
+
+	return nil
+}
@@ -6,16 +6,113 @@ package database
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
+type AlertChannel struct {
+	ID             int64
+	SubscriptionID int64
+	Type           string
+	Target         string
+	Secret         string
+}
+
+type AlertDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	ChannelType    string
+	TargetStreamer string
+	Success        bool
+	Error          *string
+	Created        time.Time
+}
+
+type AlertPending struct {
+	SubscriptionID int64
+	TargetStream   string
+	LastCycleID    uuid.UUID
+	History        []bool
+	Updated        time.Time
+}
+
+type AlertQuery struct {
+	ID             int64
+	SubscriptionID int64
+	Query          string
+}
+
+type AlertSubscription struct {
+	ID            int64
+	Streamer      string
+	Enabled       bool
+	Created       time.Time
+	Updated       time.Time
+	ConfirmHits   int32
+	ConfirmWindow int32
+	RequireMutual bool
+}
+
 type SchemaVersion struct {
 	Version int32
 }
 
+type Sighting struct {
+	ID                 int64
+	StreamID           string
+	Nickname           string
+	NormalizedNickname string
+	Confidence         float64
+	ObservedAt         time.Time
+	CycleID            uuid.UUID
+	Slot               *int32
+	Bbox               []int32
+}
+
 type Stream struct {
-	ID          string
-	Updated     time.Time
-	Url         *string
-	Online      bool
-	PlayerNames []string
+	ID                string
+	Updated           time.Time
+	Url               *string
+	Online            bool
+	PlayerNames       []string
+	LastCycleID       *uuid.UUID
+	NextScanAt        time.Time
+	ScanInterval      int32
+	LastScanAt        *time.Time
+	Phase             string
+	Players           StreamPlayers
+	Language          string
+	ViewerCount       int32
+	LastErrorCategory *string
+	LastErrorAt       *time.Time
+}
+
+type StreamAnalysis struct {
+	ID            int64
+	StreamID      string
+	CycleID       uuid.UUID
+	AnalyzedAt    time.Time
+	Phase         string
+	Players       StreamPlayers
+	SkipReason    string
+	ErrorCategory *string
+}
+
+type StreamNickname struct {
+	StreamID           string
+	Nickname           string
+	NormalizedNickname string
+}
+
+type StreamerAlias struct {
+	ID                 int64
+	Streamer           string
+	Nickname           string
+	NormalizedNickname string
+	Source             string
+	Confidence         float64
+	Sightings          int32
+	LastSeen           *time.Time
+	Created            time.Time
+	Updated            time.Time
 }
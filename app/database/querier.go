@@ -10,14 +10,182 @@ import (
 )
 
 type Querier interface {
+	//CreateAlertChannel
+	//
+	//  INSERT INTO alert_channels(subscription_id, type, target, secret)
+	//  VALUES ($1, $2, $3, $4)
+	//  RETURNING id, subscription_id, type, target, secret
+	CreateAlertChannel(ctx context.Context, arg CreateAlertChannelParams) (AlertChannel, error)
+	//CreateAlertDelivery
+	//
+	//  INSERT INTO alert_deliveries(subscription_id, channel_type, target_streamer, success, error)
+	//  VALUES ($1, $2, $3, $4, $5)
+	CreateAlertDelivery(ctx context.Context, arg CreateAlertDeliveryParams) error
+	//CreateAlertQuery
+	//
+	//  INSERT INTO alert_queries(subscription_id, query)
+	//  VALUES ($1, $2)
+	//  ON CONFLICT DO NOTHING
+	CreateAlertQuery(ctx context.Context, arg CreateAlertQueryParams) error
+	//CreateAlertSubscription
+	//
+	//  INSERT INTO alert_subscriptions(streamer, enabled, confirm_hits, confirm_window, require_mutual)
+	//  VALUES ($1, $2, $3, $4, $5)
+	//  RETURNING id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+	CreateAlertSubscription(ctx context.Context, arg CreateAlertSubscriptionParams) (AlertSubscription, error)
+	//CreateSighting
+	//
+	//  INSERT INTO sightings(stream_id, nickname, normalized_nickname, confidence, observed_at, cycle_id, slot, bbox)
+	//  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	CreateSighting(ctx context.Context, arg CreateSightingParams) error
 	//CreateStream
 	//
 	//  INSERT INTO streams(id, updated)
 	//  VALUES ($1, $2) ON CONFLICT (id) DO NOTHING
 	CreateStream(ctx context.Context, arg CreateStreamParams) error
+	//CreateStreamAnalysis
+	//
+	//  INSERT INTO stream_analyses(stream_id, cycle_id, analyzed_at, phase, players, skip_reason, error_category)
+	//  VALUES ($1, $2, $3, $4, $5, $6, $7)
+	CreateStreamAnalysis(ctx context.Context, arg CreateStreamAnalysisParams) error
+	//CreateStreamNicknames
+	//
+	//  INSERT INTO stream_nicknames(stream_id, nickname, normalized_nickname)
+	//  SELECT $1, unnest($2::VARCHAR(255)[]), unnest($3::VARCHAR(255)[])
+	//  ON CONFLICT DO NOTHING
+	CreateStreamNicknames(ctx context.Context, arg CreateStreamNicknamesParams) error
+	//CreateStreamerAlias
+	//
+	//  INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence)
+	//  VALUES ($1, $2, $3, 'manual', 1)
+	//  ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+	//    SET nickname   = EXCLUDED.nickname,
+	//        source     = 'manual',
+	//        confidence = 1,
+	//        updated    = CURRENT_TIMESTAMP
+	//  RETURNING id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+	CreateStreamerAlias(ctx context.Context, arg CreateStreamerAliasParams) (StreamerAlias, error)
+	//DeleteAlertChannels
+	//
+	//  DELETE
+	//  FROM alert_channels
+	//  WHERE subscription_id = $1
+	DeleteAlertChannels(ctx context.Context, subscriptionID int64) error
+	//DeleteAlertPending
+	//
+	//  DELETE
+	//  FROM alert_pending
+	//  WHERE subscription_id = $1
+	//    AND target_stream = $2
+	DeleteAlertPending(ctx context.Context, arg DeleteAlertPendingParams) error
+	//DeleteAlertQueries
+	//
+	//  DELETE
+	//  FROM alert_queries
+	//  WHERE subscription_id = $1
+	DeleteAlertQueries(ctx context.Context, subscriptionID int64) error
+	//DeleteAlertSubscription
+	//
+	//  DELETE
+	//  FROM alert_subscriptions
+	//  WHERE id = $1
+	DeleteAlertSubscription(ctx context.Context, id int64) (int64, error)
+	// keeps only the newest analyses of the stream
+	//
+	//  DELETE
+	//  FROM stream_analyses AS sa
+	//  WHERE sa.stream_id = $1::VARCHAR
+	//    AND sa.id NOT IN (SELECT recent.id
+	//                      FROM stream_analyses AS recent
+	//                      WHERE recent.stream_id = $1::VARCHAR
+	//                      ORDER BY recent.analyzed_at DESC, recent.id DESC
+	//                      LIMIT $2::INTEGER)
+	DeleteOldStreamAnalyses(ctx context.Context, arg DeleteOldStreamAnalysesParams) error
+	//DeleteStreamNicknames
+	//
+	//  DELETE
+	//  FROM stream_nicknames
+	//  WHERE stream_id = $1
+	DeleteStreamNicknames(ctx context.Context, streamID string) error
+	//DeleteStreamerAlias
+	//
+	//  DELETE
+	//  FROM streamer_aliases
+	//  WHERE id = $1
+	//    AND streamer = $2
+	DeleteStreamerAlias(ctx context.Context, arg DeleteStreamerAliasParams) (int64, error)
+	//GetAlertChannels
+	//
+	//  SELECT id, subscription_id, type, target, secret
+	//  FROM alert_channels
+	//  ORDER BY id
+	GetAlertChannels(ctx context.Context) ([]AlertChannel, error)
+	//GetAlertDeliveries
+	//
+	//  SELECT id, subscription_id, channel_type, target_streamer, success, error, created
+	//  FROM alert_deliveries
+	//  WHERE subscription_id = $1
+	//  ORDER BY id DESC
+	//  LIMIT $2
+	GetAlertDeliveries(ctx context.Context, arg GetAlertDeliveriesParams) ([]AlertDelivery, error)
+	//GetAlertPending
+	//
+	//  SELECT subscription_id, target_stream, last_cycle_id, history, updated
+	//  FROM alert_pending
+	//  WHERE subscription_id = $1
+	GetAlertPending(ctx context.Context, subscriptionID int64) ([]AlertPending, error)
+	//GetAlertQueries
+	//
+	//  SELECT id, subscription_id, query
+	//  FROM alert_queries
+	//  ORDER BY id
+	GetAlertQueries(ctx context.Context) ([]AlertQuery, error)
+	//GetAlertQueriesBySubscription
+	//
+	//  SELECT id, subscription_id, query
+	//  FROM alert_queries
+	//  WHERE subscription_id = $1
+	//  ORDER BY id
+	GetAlertQueriesBySubscription(ctx context.Context, subscriptionID int64) ([]AlertQuery, error)
+	//GetAlertRelevantStreamIDs
+	//
+	//  SELECT streamer AS id
+	//  FROM alert_subscriptions
+	//  WHERE enabled = true
+	//  UNION
+	//  SELECT target_stream AS id
+	//  FROM alert_pending
+	GetAlertRelevantStreamIDs(ctx context.Context) ([]string, error)
+	//GetAlertSubscription
+	//
+	//  SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+	//  FROM alert_subscriptions
+	//  WHERE id = $1
+	GetAlertSubscription(ctx context.Context, id int64) (AlertSubscription, error)
+	//GetAlertSubscriptions
+	//
+	//  SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+	//  FROM alert_subscriptions
+	//  ORDER BY id
+	GetAlertSubscriptions(ctx context.Context) ([]AlertSubscription, error)
+	//GetDueStreams
+	//
+	//  SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+	//  FROM streams
+	//  WHERE online = true
+	//    AND next_scan_at <= $1::TIMESTAMP
+	//  ORDER BY next_scan_at
+	GetDueStreams(ctx context.Context, now time.Time) ([]Stream, error)
+	//GetEnabledAlertSubscriptions
+	//
+	//  SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+	//  FROM alert_subscriptions
+	//  WHERE enabled = true
+	//  ORDER BY id
+	GetEnabledAlertSubscriptions(ctx context.Context) ([]AlertSubscription, error)
 	//GetOnlineStreams
 	//
-	//  SELECT id, updated, url, online, player_names
+	//  SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
 	//  FROM streams
 	//  WHERE online = true
 	GetOnlineStreams(ctx context.Context) ([]Stream, error)
@@ -26,31 +194,200 @@ type Querier interface {
 	//  SELECT version
 	//  FROM schema_version
 	GetSchemaVersion(ctx context.Context) (int32, error)
-	//SearchStreamsByNickname
+	//GetStream
 	//
-	//  SELECT id, updated, url, online, player_names
+	//  SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
 	//  FROM streams
-	//  WHERE online = true
-	//    AND EXISTS (SELECT 1
-	//                FROM unnest(player_names) AS nickname
-	//                WHERE levenshtein(lower(nickname), lower($1::VARCHAR(255))) < $2::INTEGER OR
-	//                                                                                            lower(nickname) LIKE '%' ||
-	//                                                                                                                 lower($1::VARCHAR(255)) ||
-	//                                                                                                                 '%')
-	//    LIMIT $3::INTEGER
+	//  WHERE id = $1
+	GetStream(ctx context.Context, id string) (Stream, error)
+	//GetStreamAnalyses
+	//
+	//  SELECT id, stream_id, cycle_id, analyzed_at, phase, players, skip_reason, error_category
+	//  FROM stream_analyses
+	//  WHERE stream_id = $1
+	//  ORDER BY analyzed_at DESC, id DESC
+	//  LIMIT $2::INTEGER
+	GetStreamAnalyses(ctx context.Context, arg GetStreamAnalysesParams) ([]StreamAnalysis, error)
+	//GetStreamSightings
+	//
+	//  SELECT id, stream_id, nickname, normalized_nickname, confidence, observed_at, cycle_id, slot, bbox
+	//  FROM sightings
+	//  WHERE stream_id = $1
+	//    AND observed_at >= $2
+	//  ORDER BY observed_at DESC
+	GetStreamSightings(ctx context.Context, arg GetStreamSightingsParams) ([]Sighting, error)
+	//GetStreamerAliases
+	//
+	//  SELECT id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+	//  FROM streamer_aliases
+	//  WHERE streamer = $1
+	//  ORDER BY confidence DESC, id
+	GetStreamerAliases(ctx context.Context, streamer string) ([]StreamerAlias, error)
+	//GetStreamerNicknames
+	//
+	//  SELECT s.streamer, q.query
+	//  FROM alert_queries q
+	//         JOIN alert_subscriptions s ON s.id = q.subscription_id
+	//  ORDER BY q.id
+	GetStreamerNicknames(ctx context.Context) ([]GetStreamerNicknamesRow, error)
+	//GetStreamsByIDs
+	//
+	//  SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+	//  FROM streams
+	//  WHERE id = ANY ($1::VARCHAR(255)[])
+	GetStreamsByIDs(ctx context.Context, ids []string) ([]Stream, error)
+	//GetTrustedStreamerAliases
+	//
+	//  SELECT id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+	//  FROM streamer_aliases
+	//  WHERE confidence >= $1::DOUBLE PRECISION
+	//  ORDER BY streamer, confidence DESC, id
+	GetTrustedStreamerAliases(ctx context.Context, minConfidence float64) ([]StreamerAlias, error)
+	// keyset pagination, the cursor is the sort key and the id of the last stream of the previous page
+	//
+	//  SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+	//  FROM streams
+	//  WHERE ($1::BOOLEAN IS NULL OR online = $1::BOOLEAN)
+	//    AND ($2::BOOLEAN IS NULL OR (cardinality(player_names) > 0) = $2::BOOLEAN)
+	//    AND ($3::TIMESTAMP IS NULL OR last_scan_at > $3::TIMESTAMP)
+	//    AND ($4::TIMESTAMP IS NULL OR last_scan_at < $4::TIMESTAMP)
+	//    AND ($5::VARCHAR IS NULL OR language = $5::VARCHAR)
+	//    AND ($6::INTEGER IS NULL OR viewer_count >= $6::INTEGER)
+	//    AND ($7::INTEGER IS NULL OR viewer_count <= $7::INTEGER)
+	//    AND ($8::VARCHAR IS NULL OR CASE $9::VARCHAR
+	//                                                     WHEN 'viewers' THEN (viewer_count, id) <
+	//                                                                         ($10::INTEGER, $8::VARCHAR)
+	//                                                     WHEN 'last_analyzed' THEN (coalesce(last_scan_at, 'epoch'), id) <
+	//                                                                               ($11::TIMESTAMP, $8::VARCHAR)
+	//                                                     ELSE id > $8::VARCHAR END)
+	//  ORDER BY CASE WHEN $9::VARCHAR = 'viewers' THEN viewer_count END DESC,
+	//           CASE WHEN $9::VARCHAR = 'last_analyzed' THEN coalesce(last_scan_at, 'epoch') END DESC,
+	//           CASE WHEN $9::VARCHAR = 'name' THEN id END,
+	//           id DESC
+	//  LIMIT $12::INTEGER
+	ListStreams(ctx context.Context, arg ListStreamsParams) ([]Stream, error)
+	// counts at most one sighting per session_gap, so that a single long lobby does not inflate the confidence
+	//
+	//  INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen)
+	//  VALUES ($1, $2, $3, 'auto', 1 / (1 + $4::DOUBLE PRECISION), 1,
+	//          $5::TIMESTAMP)
+	//  ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+	//    SET sightings  = streamer_aliases.sightings + 1,
+	//        confidence = GREATEST(streamer_aliases.confidence,
+	//                              (streamer_aliases.sightings + 1) /
+	//                              (streamer_aliases.sightings + 1 + $4::DOUBLE PRECISION)),
+	//        last_seen  = EXCLUDED.last_seen,
+	//        updated    = CURRENT_TIMESTAMP
+	//  WHERE streamer_aliases.last_seen IS NULL
+	//     OR streamer_aliases.last_seen < EXCLUDED.last_seen - make_interval(secs => $6::INTEGER)
+	RecordStreamerAliasSighting(ctx context.Context, arg RecordStreamerAliasSightingParams) error
+	// candidates are prefiltered by the trigram index, the threshold is set by SetSimilarityThreshold
+	//
+	//  SELECT stream_id,
+	//         min(observed_at)::TIMESTAMP                  AS first_seen,
+	//         max(observed_at)::TIMESTAMP                  AS last_seen,
+	//         count(*)::INTEGER                            AS sightings_count,
+	//         array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+	//  FROM sightings
+	//  WHERE observed_at BETWEEN $1::TIMESTAMP AND $2::TIMESTAMP
+	//    AND (normalized_nickname % $3::VARCHAR(255) OR
+	//         normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%')
+	//    AND (levenshtein(normalized_nickname, $3::VARCHAR(255)) < $5::INTEGER OR
+	//         normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%')
+	//  GROUP BY stream_id
+	//  ORDER BY min(CASE
+	//                 WHEN normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%' THEN 0
+	//                 ELSE levenshtein(normalized_nickname, $3::VARCHAR(255)) END),
+	//           last_seen DESC
+	//  LIMIT $6::INTEGER
+	SearchSightingsByNickname(ctx context.Context, arg SearchSightingsByNicknameParams) ([]SearchSightingsByNicknameRow, error)
+	// compares the query with every nickname of the window, for the queries too short for the trigram index
+	//
+	//  SELECT stream_id,
+	//         min(observed_at)::TIMESTAMP                  AS first_seen,
+	//         max(observed_at)::TIMESTAMP                  AS last_seen,
+	//         count(*)::INTEGER                            AS sightings_count,
+	//         array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+	//  FROM sightings
+	//  WHERE observed_at BETWEEN $1::TIMESTAMP AND $2::TIMESTAMP
+	//    AND (levenshtein(normalized_nickname, $3::VARCHAR(255)) < $4::INTEGER OR
+	//         normalized_nickname LIKE '%' || $5::VARCHAR(255) || '%')
+	//  GROUP BY stream_id
+	//  ORDER BY min(CASE
+	//                 WHEN normalized_nickname LIKE '%' || $5::VARCHAR(255) || '%' THEN 0
+	//                 ELSE levenshtein(normalized_nickname, $3::VARCHAR(255)) END),
+	//           last_seen DESC
+	//  LIMIT $6::INTEGER
+	SearchSightingsByNicknameExhaustive(ctx context.Context, arg SearchSightingsByNicknameExhaustiveParams) ([]SearchSightingsByNicknameExhaustiveRow, error)
+	// candidates are prefiltered by the trigram index, the threshold is set by SetSimilarityThreshold
+	//
+	//  SELECT streams.id, streams.updated, streams.url, streams.online, streams.player_names, streams.last_cycle_id, streams.next_scan_at, streams.scan_interval, streams.last_scan_at, streams.phase, streams.players, streams.language, streams.viewer_count, streams.last_error_category, streams.last_error_at
+	//  FROM streams
+	//         JOIN (SELECT stream_id,
+	//                      min(CASE
+	//                            WHEN normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%' THEN 0
+	//                            ELSE levenshtein(normalized_nickname, $2::VARCHAR(255)) END) AS distance
+	//               FROM stream_nicknames
+	//               WHERE normalized_nickname % $2::VARCHAR(255)
+	//                  OR normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%'
+	//               GROUP BY stream_id) matches ON matches.stream_id = streams.id
+	//  WHERE streams.online = true
+	//    AND matches.distance < $3::INTEGER
+	//  ORDER BY matches.distance
+	//  LIMIT $4::INTEGER
 	SearchStreamsByNickname(ctx context.Context, arg SearchStreamsByNicknameParams) ([]Stream, error)
+	// compares the query with every nickname, for the queries too short for the trigram index
+	//
+	//  SELECT streams.id, streams.updated, streams.url, streams.online, streams.player_names, streams.last_cycle_id, streams.next_scan_at, streams.scan_interval, streams.last_scan_at, streams.phase, streams.players, streams.language, streams.viewer_count, streams.last_error_category, streams.last_error_at
+	//  FROM streams
+	//         JOIN (SELECT stream_id,
+	//                      min(CASE
+	//                            WHEN normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%' THEN 0
+	//                            ELSE levenshtein(normalized_nickname, $2::VARCHAR(255)) END) AS distance
+	//               FROM stream_nicknames
+	//               GROUP BY stream_id) matches ON matches.stream_id = streams.id
+	//  WHERE streams.online = true
+	//    AND matches.distance < $3::INTEGER
+	//  ORDER BY matches.distance
+	//  LIMIT $4::INTEGER
+	SearchStreamsByNicknameExhaustive(ctx context.Context, arg SearchStreamsByNicknameExhaustiveParams) ([]Stream, error)
 	//SetSchemaVersion
 	//
 	//  UPDATE schema_version
 	//  SET version = $1
 	SetSchemaVersion(ctx context.Context, version int32) error
+	// sets the trigram similarity used by the % operator until the end of the transaction
+	//
+	//  SELECT set_config('pg_trgm.similarity_threshold', $1::TEXT, true)
+	SetSimilarityThreshold(ctx context.Context, threshold string) error
+	//SetStreamError
+	//
+	//  UPDATE streams
+	//  SET last_error_category = $2,
+	//      last_error_at       = $3
+	//  WHERE id = $1
+	SetStreamError(ctx context.Context, arg SetStreamErrorParams) error
 	//SetStreamOnline
 	//
 	//  UPDATE streams
-	//  SET online  = true,
-	//      updated = $2
+	//  SET online       = true,
+	//      updated      = $2,
+	//      language     = $3,
+	//      viewer_count = $4
 	//  WHERE id = $1
 	SetStreamOnline(ctx context.Context, arg SetStreamOnlineParams) error
+	//UpdateAlertSubscription
+	//
+	//  UPDATE alert_subscriptions
+	//  SET streamer       = $2,
+	//      enabled        = $3,
+	//      confirm_hits   = $4,
+	//      confirm_window = $5,
+	//      require_mutual = $6,
+	//      updated        = CURRENT_TIMESTAMP
+	//  WHERE id = $1
+	//  RETURNING id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+	UpdateAlertSubscription(ctx context.Context, arg UpdateAlertSubscriptionParams) (AlertSubscription, error)
 	//UpdateStaleStreams
 	//
 	//  UPDATE streams
@@ -60,15 +397,35 @@ type Querier interface {
 	//UpdateStreamData
 	//
 	//  UPDATE streams
-	//  SET player_names = $2
+	//  SET player_names  = $2,
+	//      last_cycle_id = $3,
+	//      phase         = $4,
+	//      players       = $5
 	//  WHERE id = $1
 	UpdateStreamData(ctx context.Context, arg UpdateStreamDataParams) error
+	//UpdateStreamSchedule
+	//
+	//  UPDATE streams
+	//  SET next_scan_at  = $2,
+	//      scan_interval = $3,
+	//      last_scan_at  = $4
+	//  WHERE id = $1
+	UpdateStreamSchedule(ctx context.Context, arg UpdateStreamScheduleParams) error
 	//UpdateStreamUrl
 	//
 	//  UPDATE streams
 	//  SET url = $2
 	//  WHERE id = $1
 	UpdateStreamUrl(ctx context.Context, arg UpdateStreamUrlParams) error
+	//UpsertAlertPending
+	//
+	//  INSERT INTO alert_pending(subscription_id, target_stream, last_cycle_id, history, updated)
+	//  VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+	//  ON CONFLICT (subscription_id, target_stream) DO UPDATE
+	//    SET last_cycle_id = excluded.last_cycle_id,
+	//        history       = excluded.history,
+	//        updated       = excluded.updated
+	UpsertAlertPending(ctx context.Context, arg UpsertAlertPendingParams) error
 }
 
 var _ Querier = (*Queries)(nil)
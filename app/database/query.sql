@@ -4,13 +4,18 @@ VALUES ($1, $2) ON CONFLICT (id) DO NOTHING;
 
 -- name: SetStreamOnline :exec
 UPDATE streams
-SET online  = true,
-    updated = $2
+SET online       = true,
+    updated      = $2,
+    language     = $3,
+    viewer_count = $4
 WHERE id = $1;
 
 -- name: UpdateStreamData :exec
 UPDATE streams
-SET player_names = $2
+SET player_names  = $2,
+    last_cycle_id = $3,
+    phase         = $4,
+    players       = $5
 WHERE id = $1;
 
 -- name: UpdateStreamUrl :exec
@@ -28,17 +33,284 @@ SELECT *
 FROM streams
 WHERE online = true;
 
--- name: SearchStreamsByNickname :many
+-- name: ListStreams :many
+-- keyset pagination, the cursor is the sort key and the id of the last stream of the previous page
+SELECT *
+FROM streams
+WHERE (sqlc.narg(online)::BOOLEAN IS NULL OR online = sqlc.narg(online)::BOOLEAN)
+  AND (sqlc.narg(has_players)::BOOLEAN IS NULL OR (cardinality(player_names) > 0) = sqlc.narg(has_players)::BOOLEAN)
+  AND (sqlc.narg(analyzed_after)::TIMESTAMP IS NULL OR last_scan_at > sqlc.narg(analyzed_after)::TIMESTAMP)
+  AND (sqlc.narg(analyzed_before)::TIMESTAMP IS NULL OR last_scan_at < sqlc.narg(analyzed_before)::TIMESTAMP)
+  AND (sqlc.narg(language)::VARCHAR IS NULL OR language = sqlc.narg(language)::VARCHAR)
+  AND (sqlc.narg(min_viewers)::INTEGER IS NULL OR viewer_count >= sqlc.narg(min_viewers)::INTEGER)
+  AND (sqlc.narg(max_viewers)::INTEGER IS NULL OR viewer_count <= sqlc.narg(max_viewers)::INTEGER)
+  AND (sqlc.narg(cursor_id)::VARCHAR IS NULL OR CASE @sort::VARCHAR
+                                                   WHEN 'viewers' THEN (viewer_count, id) <
+                                                                       (@cursor_viewers::INTEGER, sqlc.narg(cursor_id)::VARCHAR)
+                                                   WHEN 'last_analyzed' THEN (coalesce(last_scan_at, 'epoch'), id) <
+                                                                             (@cursor_analyzed::TIMESTAMP, sqlc.narg(cursor_id)::VARCHAR)
+                                                   ELSE id > sqlc.narg(cursor_id)::VARCHAR END)
+ORDER BY CASE WHEN @sort::VARCHAR = 'viewers' THEN viewer_count END DESC,
+         CASE WHEN @sort::VARCHAR = 'last_analyzed' THEN coalesce(last_scan_at, 'epoch') END DESC,
+         CASE WHEN @sort::VARCHAR = 'name' THEN id END,
+         id DESC
+LIMIT @max_results::INTEGER;
+
+-- name: GetDueStreams :many
 SELECT *
 FROM streams
 WHERE online = true
-  AND EXISTS (SELECT 1
-              FROM unnest(player_names) AS nickname
-              WHERE levenshtein(lower(nickname), lower(@query::VARCHAR(255))) < @distance::INTEGER OR
-                                                                                          lower(nickname) LIKE '%' ||
-                                                                                                               lower(@query::VARCHAR(255)) ||
-                                                                                                               '%')
-  LIMIT @max_results::INTEGER;
+  AND next_scan_at <= @now::TIMESTAMP
+ORDER BY next_scan_at;
+
+-- name: UpdateStreamSchedule :exec
+UPDATE streams
+SET next_scan_at  = $2,
+    scan_interval = $3,
+    last_scan_at  = $4
+WHERE id = $1;
+
+-- name: GetStream :one
+SELECT *
+FROM streams
+WHERE id = $1;
+
+-- name: SetStreamError :exec
+UPDATE streams
+SET last_error_category = $2,
+    last_error_at       = $3
+WHERE id = $1;
+
+-- name: CreateStreamAnalysis :exec
+INSERT INTO stream_analyses(stream_id, cycle_id, analyzed_at, phase, players, skip_reason, error_category)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: DeleteOldStreamAnalyses :exec
+-- keeps only the newest analyses of the stream
+DELETE
+FROM stream_analyses AS sa
+WHERE sa.stream_id = @stream_id::VARCHAR
+  AND sa.id NOT IN (SELECT recent.id
+                    FROM stream_analyses AS recent
+                    WHERE recent.stream_id = @stream_id::VARCHAR
+                    ORDER BY recent.analyzed_at DESC, recent.id DESC
+                    LIMIT @keep::INTEGER);
+
+-- name: GetStreamAnalyses :many
+SELECT *
+FROM stream_analyses
+WHERE stream_id = @stream_id
+ORDER BY analyzed_at DESC, id DESC
+LIMIT @max_results::INTEGER;
+
+-- name: SetSimilarityThreshold :exec
+-- sets the trigram similarity used by the % operator until the end of the transaction
+SELECT set_config('pg_trgm.similarity_threshold', @threshold::TEXT, true);
+
+-- name: DeleteStreamNicknames :exec
+DELETE
+FROM stream_nicknames
+WHERE stream_id = $1;
+
+-- name: CreateStreamNicknames :exec
+INSERT INTO stream_nicknames(stream_id, nickname, normalized_nickname)
+SELECT @stream_id, unnest(@nicknames::VARCHAR(255)[]), unnest(@normalized_nicknames::VARCHAR(255)[])
+ON CONFLICT DO NOTHING;
+
+-- name: SearchStreamsByNickname :many
+-- candidates are prefiltered by the trigram index, the threshold is set by SetSimilarityThreshold
+SELECT streams.*
+FROM streams
+       JOIN (SELECT stream_id,
+                    min(CASE
+                          WHEN normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%' THEN 0
+                          ELSE levenshtein(normalized_nickname, @query::VARCHAR(255)) END) AS distance
+             FROM stream_nicknames
+             WHERE normalized_nickname % @query::VARCHAR(255)
+                OR normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%'
+             GROUP BY stream_id) matches ON matches.stream_id = streams.id
+WHERE streams.online = true
+  AND matches.distance < @distance::INTEGER
+ORDER BY matches.distance
+LIMIT @max_results::INTEGER;
+
+-- name: SearchStreamsByNicknameExhaustive :many
+-- compares the query with every nickname, for the queries too short for the trigram index
+SELECT streams.*
+FROM streams
+       JOIN (SELECT stream_id,
+                    min(CASE
+                          WHEN normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%' THEN 0
+                          ELSE levenshtein(normalized_nickname, @query::VARCHAR(255)) END) AS distance
+             FROM stream_nicknames
+             GROUP BY stream_id) matches ON matches.stream_id = streams.id
+WHERE streams.online = true
+  AND matches.distance < @distance::INTEGER
+ORDER BY matches.distance
+LIMIT @max_results::INTEGER;
+
+-- name: CreateSighting :exec
+INSERT INTO sightings(stream_id, nickname, normalized_nickname, confidence, observed_at, cycle_id, slot, bbox)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetStreamSightings :many
+SELECT *
+FROM sightings
+WHERE stream_id = $1
+  AND observed_at >= $2
+ORDER BY observed_at DESC;
+
+-- name: SearchSightingsByNickname :many
+-- candidates are prefiltered by the trigram index, the threshold is set by SetSimilarityThreshold
+SELECT stream_id,
+       min(observed_at)::TIMESTAMP                  AS first_seen,
+       max(observed_at)::TIMESTAMP                  AS last_seen,
+       count(*)::INTEGER                            AS sightings_count,
+       array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+FROM sightings
+WHERE observed_at BETWEEN @since::TIMESTAMP AND @until::TIMESTAMP
+  AND (normalized_nickname % @query::VARCHAR(255) OR
+       normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%')
+  AND (levenshtein(normalized_nickname, @query::VARCHAR(255)) < @distance::INTEGER OR
+       normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%')
+GROUP BY stream_id
+ORDER BY min(CASE
+               WHEN normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%' THEN 0
+               ELSE levenshtein(normalized_nickname, @query::VARCHAR(255)) END),
+         last_seen DESC
+LIMIT @max_results::INTEGER;
+
+-- name: SearchSightingsByNicknameExhaustive :many
+-- compares the query with every nickname of the window, for the queries too short for the trigram index
+SELECT stream_id,
+       min(observed_at)::TIMESTAMP                  AS first_seen,
+       max(observed_at)::TIMESTAMP                  AS last_seen,
+       count(*)::INTEGER                            AS sightings_count,
+       array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+FROM sightings
+WHERE observed_at BETWEEN @since::TIMESTAMP AND @until::TIMESTAMP
+  AND (levenshtein(normalized_nickname, @query::VARCHAR(255)) < @distance::INTEGER OR
+       normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%')
+GROUP BY stream_id
+ORDER BY min(CASE
+               WHEN normalized_nickname LIKE '%' || @pattern::VARCHAR(255) || '%' THEN 0
+               ELSE levenshtein(normalized_nickname, @query::VARCHAR(255)) END),
+         last_seen DESC
+LIMIT @max_results::INTEGER;
+
+-- name: CreateAlertSubscription :one
+INSERT INTO alert_subscriptions(streamer, enabled, confirm_hits, confirm_window, require_mutual)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetAlertSubscriptions :many
+SELECT *
+FROM alert_subscriptions
+ORDER BY id;
+
+-- name: GetEnabledAlertSubscriptions :many
+SELECT *
+FROM alert_subscriptions
+WHERE enabled = true
+ORDER BY id;
+
+-- name: GetAlertSubscription :one
+SELECT *
+FROM alert_subscriptions
+WHERE id = $1;
+
+-- name: UpdateAlertSubscription :one
+UPDATE alert_subscriptions
+SET streamer       = $2,
+    enabled        = $3,
+    confirm_hits   = $4,
+    confirm_window = $5,
+    require_mutual = $6,
+    updated        = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAlertSubscription :execrows
+DELETE
+FROM alert_subscriptions
+WHERE id = $1;
+
+-- name: GetStreamerNicknames :many
+SELECT s.streamer, q.query
+FROM alert_queries q
+       JOIN alert_subscriptions s ON s.id = q.subscription_id
+ORDER BY q.id;
+
+-- name: CreateAlertQuery :exec
+INSERT INTO alert_queries(subscription_id, query)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteAlertQueries :exec
+DELETE
+FROM alert_queries
+WHERE subscription_id = $1;
+
+-- name: GetAlertQueries :many
+SELECT *
+FROM alert_queries
+ORDER BY id;
+
+-- name: GetAlertQueriesBySubscription :many
+SELECT *
+FROM alert_queries
+WHERE subscription_id = $1
+ORDER BY id;
+
+-- name: CreateAlertChannel :one
+INSERT INTO alert_channels(subscription_id, type, target, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: DeleteAlertChannels :exec
+DELETE
+FROM alert_channels
+WHERE subscription_id = $1;
+
+-- name: GetAlertChannels :many
+SELECT *
+FROM alert_channels
+ORDER BY id;
+
+-- name: CreateAlertDelivery :exec
+INSERT INTO alert_deliveries(subscription_id, channel_type, target_streamer, success, error)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetAlertDeliveries :many
+SELECT *
+FROM alert_deliveries
+WHERE subscription_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: GetStreamsByIDs :many
+SELECT *
+FROM streams
+WHERE id = ANY (@ids::VARCHAR(255)[]);
+
+-- name: GetAlertPending :many
+SELECT *
+FROM alert_pending
+WHERE subscription_id = $1;
+
+-- name: UpsertAlertPending :exec
+INSERT INTO alert_pending(subscription_id, target_stream, last_cycle_id, history, updated)
+VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+ON CONFLICT (subscription_id, target_stream) DO UPDATE
+  SET last_cycle_id = excluded.last_cycle_id,
+      history       = excluded.history,
+      updated       = excluded.updated;
+
+-- name: DeleteAlertPending :exec
+DELETE
+FROM alert_pending
+WHERE subscription_id = $1
+  AND target_stream = $2;
 
 -- name: GetSchemaVersion :one
 SELECT version
@@ -47,3 +319,54 @@ FROM schema_version;
 -- name: SetSchemaVersion :exec
 UPDATE schema_version
 SET version = $1;
+
+-- name: CreateStreamerAlias :one
+INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence)
+VALUES (@streamer, @nickname, @normalized_nickname, 'manual', 1)
+ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+  SET nickname   = EXCLUDED.nickname,
+      source     = 'manual',
+      confidence = 1,
+      updated    = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetStreamerAliases :many
+SELECT *
+FROM streamer_aliases
+WHERE streamer = $1
+ORDER BY confidence DESC, id;
+
+-- name: GetTrustedStreamerAliases :many
+SELECT *
+FROM streamer_aliases
+WHERE confidence >= @min_confidence::DOUBLE PRECISION
+ORDER BY streamer, confidence DESC, id;
+
+-- name: DeleteStreamerAlias :execrows
+DELETE
+FROM streamer_aliases
+WHERE id = $1
+  AND streamer = $2;
+
+-- name: RecordStreamerAliasSighting :exec
+-- counts at most one sighting per session_gap, so that a single long lobby does not inflate the confidence
+INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen)
+VALUES (@streamer, @nickname, @normalized_nickname, 'auto', 1 / (1 + @saturation::DOUBLE PRECISION), 1,
+        @observed_at::TIMESTAMP)
+ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+  SET sightings  = streamer_aliases.sightings + 1,
+      confidence = GREATEST(streamer_aliases.confidence,
+                            (streamer_aliases.sightings + 1) /
+                            (streamer_aliases.sightings + 1 + @saturation::DOUBLE PRECISION)),
+      last_seen  = EXCLUDED.last_seen,
+      updated    = CURRENT_TIMESTAMP
+WHERE streamer_aliases.last_seen IS NULL
+   OR streamer_aliases.last_seen < EXCLUDED.last_seen - make_interval(secs => @session_gap::INTEGER);
+
+-- name: GetAlertRelevantStreamIDs :many
+SELECT streamer AS id
+FROM alert_subscriptions
+WHERE enabled = true
+UNION
+SELECT target_stream AS id
+FROM alert_pending;
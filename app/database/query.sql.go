@@ -8,40 +8,1109 @@ package database
 import (
 	"context"
 	"time"
+
+	"github.com/google/uuid"
 )
 
-const createStream = `-- name: CreateStream :exec
-INSERT INTO streams(id, updated)
-VALUES ($1, $2) ON CONFLICT (id) DO NOTHING
+const createAlertChannel = `-- name: CreateAlertChannel :one
+INSERT INTO alert_channels(subscription_id, type, target, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING id, subscription_id, type, target, secret
+`
+
+type CreateAlertChannelParams struct {
+	SubscriptionID int64
+	Type           string
+	Target         string
+	Secret         string
+}
+
+// CreateAlertChannel
+//
+//	INSERT INTO alert_channels(subscription_id, type, target, secret)
+//	VALUES ($1, $2, $3, $4)
+//	RETURNING id, subscription_id, type, target, secret
+func (q *Queries) CreateAlertChannel(ctx context.Context, arg CreateAlertChannelParams) (AlertChannel, error) {
+	row := q.db.QueryRow(ctx, createAlertChannel,
+		arg.SubscriptionID,
+		arg.Type,
+		arg.Target,
+		arg.Secret,
+	)
+	var i AlertChannel
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.Type,
+		&i.Target,
+		&i.Secret,
+	)
+	return i, err
+}
+
+const createAlertDelivery = `-- name: CreateAlertDelivery :exec
+INSERT INTO alert_deliveries(subscription_id, channel_type, target_streamer, success, error)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateAlertDeliveryParams struct {
+	SubscriptionID int64
+	ChannelType    string
+	TargetStreamer string
+	Success        bool
+	Error          *string
+}
+
+// CreateAlertDelivery
+//
+//	INSERT INTO alert_deliveries(subscription_id, channel_type, target_streamer, success, error)
+//	VALUES ($1, $2, $3, $4, $5)
+func (q *Queries) CreateAlertDelivery(ctx context.Context, arg CreateAlertDeliveryParams) error {
+	_, err := q.db.Exec(ctx, createAlertDelivery,
+		arg.SubscriptionID,
+		arg.ChannelType,
+		arg.TargetStreamer,
+		arg.Success,
+		arg.Error,
+	)
+	return err
+}
+
+const createAlertQuery = `-- name: CreateAlertQuery :exec
+INSERT INTO alert_queries(subscription_id, query)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type CreateAlertQueryParams struct {
+	SubscriptionID int64
+	Query          string
+}
+
+// CreateAlertQuery
+//
+//	INSERT INTO alert_queries(subscription_id, query)
+//	VALUES ($1, $2)
+//	ON CONFLICT DO NOTHING
+func (q *Queries) CreateAlertQuery(ctx context.Context, arg CreateAlertQueryParams) error {
+	_, err := q.db.Exec(ctx, createAlertQuery, arg.SubscriptionID, arg.Query)
+	return err
+}
+
+const createAlertSubscription = `-- name: CreateAlertSubscription :one
+INSERT INTO alert_subscriptions(streamer, enabled, confirm_hits, confirm_window, require_mutual)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+`
+
+type CreateAlertSubscriptionParams struct {
+	Streamer      string
+	Enabled       bool
+	ConfirmHits   int32
+	ConfirmWindow int32
+	RequireMutual bool
+}
+
+// CreateAlertSubscription
+//
+//	INSERT INTO alert_subscriptions(streamer, enabled, confirm_hits, confirm_window, require_mutual)
+//	VALUES ($1, $2, $3, $4, $5)
+//	RETURNING id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+func (q *Queries) CreateAlertSubscription(ctx context.Context, arg CreateAlertSubscriptionParams) (AlertSubscription, error) {
+	row := q.db.QueryRow(ctx, createAlertSubscription,
+		arg.Streamer,
+		arg.Enabled,
+		arg.ConfirmHits,
+		arg.ConfirmWindow,
+		arg.RequireMutual,
+	)
+	var i AlertSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Streamer,
+		&i.Enabled,
+		&i.Created,
+		&i.Updated,
+		&i.ConfirmHits,
+		&i.ConfirmWindow,
+		&i.RequireMutual,
+	)
+	return i, err
+}
+
+const createSighting = `-- name: CreateSighting :exec
+INSERT INTO sightings(stream_id, nickname, normalized_nickname, confidence, observed_at, cycle_id, slot, bbox)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateSightingParams struct {
+	StreamID           string
+	Nickname           string
+	NormalizedNickname string
+	Confidence         float64
+	ObservedAt         time.Time
+	CycleID            uuid.UUID
+	Slot               *int32
+	Bbox               []int32
+}
+
+// CreateSighting
+//
+//	INSERT INTO sightings(stream_id, nickname, normalized_nickname, confidence, observed_at, cycle_id, slot, bbox)
+//	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+func (q *Queries) CreateSighting(ctx context.Context, arg CreateSightingParams) error {
+	_, err := q.db.Exec(ctx, createSighting,
+		arg.StreamID,
+		arg.Nickname,
+		arg.NormalizedNickname,
+		arg.Confidence,
+		arg.ObservedAt,
+		arg.CycleID,
+		arg.Slot,
+		arg.Bbox,
+	)
+	return err
+}
+
+const createStream = `-- name: CreateStream :exec
+INSERT INTO streams(id, updated)
+VALUES ($1, $2) ON CONFLICT (id) DO NOTHING
+`
+
+type CreateStreamParams struct {
+	ID      string
+	Updated time.Time
+}
+
+// CreateStream
+//
+//	INSERT INTO streams(id, updated)
+//	VALUES ($1, $2) ON CONFLICT (id) DO NOTHING
+func (q *Queries) CreateStream(ctx context.Context, arg CreateStreamParams) error {
+	_, err := q.db.Exec(ctx, createStream, arg.ID, arg.Updated)
+	return err
+}
+
+const createStreamAnalysis = `-- name: CreateStreamAnalysis :exec
+INSERT INTO stream_analyses(stream_id, cycle_id, analyzed_at, phase, players, skip_reason, error_category)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateStreamAnalysisParams struct {
+	StreamID      string
+	CycleID       uuid.UUID
+	AnalyzedAt    time.Time
+	Phase         string
+	Players       StreamPlayers
+	SkipReason    string
+	ErrorCategory *string
+}
+
+// CreateStreamAnalysis
+//
+//	INSERT INTO stream_analyses(stream_id, cycle_id, analyzed_at, phase, players, skip_reason, error_category)
+//	VALUES ($1, $2, $3, $4, $5, $6, $7)
+func (q *Queries) CreateStreamAnalysis(ctx context.Context, arg CreateStreamAnalysisParams) error {
+	_, err := q.db.Exec(ctx, createStreamAnalysis,
+		arg.StreamID,
+		arg.CycleID,
+		arg.AnalyzedAt,
+		arg.Phase,
+		arg.Players,
+		arg.SkipReason,
+		arg.ErrorCategory,
+	)
+	return err
+}
+
+const createStreamNicknames = `-- name: CreateStreamNicknames :exec
+INSERT INTO stream_nicknames(stream_id, nickname, normalized_nickname)
+SELECT $1, unnest($2::VARCHAR(255)[]), unnest($3::VARCHAR(255)[])
+ON CONFLICT DO NOTHING
+`
+
+type CreateStreamNicknamesParams struct {
+	StreamID            string
+	Nicknames           []string
+	NormalizedNicknames []string
+}
+
+// CreateStreamNicknames
+//
+//	INSERT INTO stream_nicknames(stream_id, nickname, normalized_nickname)
+//	SELECT $1, unnest($2::VARCHAR(255)[]), unnest($3::VARCHAR(255)[])
+//	ON CONFLICT DO NOTHING
+func (q *Queries) CreateStreamNicknames(ctx context.Context, arg CreateStreamNicknamesParams) error {
+	_, err := q.db.Exec(ctx, createStreamNicknames, arg.StreamID, arg.Nicknames, arg.NormalizedNicknames)
+	return err
+}
+
+const createStreamerAlias = `-- name: CreateStreamerAlias :one
+INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence)
+VALUES ($1, $2, $3, 'manual', 1)
+ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+  SET nickname   = EXCLUDED.nickname,
+      source     = 'manual',
+      confidence = 1,
+      updated    = CURRENT_TIMESTAMP
+RETURNING id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+`
+
+type CreateStreamerAliasParams struct {
+	Streamer           string
+	Nickname           string
+	NormalizedNickname string
+}
+
+// CreateStreamerAlias
+//
+//	INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence)
+//	VALUES ($1, $2, $3, 'manual', 1)
+//	ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+//	  SET nickname   = EXCLUDED.nickname,
+//	      source     = 'manual',
+//	      confidence = 1,
+//	      updated    = CURRENT_TIMESTAMP
+//	RETURNING id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+func (q *Queries) CreateStreamerAlias(ctx context.Context, arg CreateStreamerAliasParams) (StreamerAlias, error) {
+	row := q.db.QueryRow(ctx, createStreamerAlias, arg.Streamer, arg.Nickname, arg.NormalizedNickname)
+	var i StreamerAlias
+	err := row.Scan(
+		&i.ID,
+		&i.Streamer,
+		&i.Nickname,
+		&i.NormalizedNickname,
+		&i.Source,
+		&i.Confidence,
+		&i.Sightings,
+		&i.LastSeen,
+		&i.Created,
+		&i.Updated,
+	)
+	return i, err
+}
+
+const deleteAlertChannels = `-- name: DeleteAlertChannels :exec
+DELETE
+FROM alert_channels
+WHERE subscription_id = $1
+`
+
+// DeleteAlertChannels
+//
+//	DELETE
+//	FROM alert_channels
+//	WHERE subscription_id = $1
+func (q *Queries) DeleteAlertChannels(ctx context.Context, subscriptionID int64) error {
+	_, err := q.db.Exec(ctx, deleteAlertChannels, subscriptionID)
+	return err
+}
+
+const deleteAlertPending = `-- name: DeleteAlertPending :exec
+DELETE
+FROM alert_pending
+WHERE subscription_id = $1
+  AND target_stream = $2
+`
+
+type DeleteAlertPendingParams struct {
+	SubscriptionID int64
+	TargetStream   string
+}
+
+// DeleteAlertPending
+//
+//	DELETE
+//	FROM alert_pending
+//	WHERE subscription_id = $1
+//	  AND target_stream = $2
+func (q *Queries) DeleteAlertPending(ctx context.Context, arg DeleteAlertPendingParams) error {
+	_, err := q.db.Exec(ctx, deleteAlertPending, arg.SubscriptionID, arg.TargetStream)
+	return err
+}
+
+const deleteAlertQueries = `-- name: DeleteAlertQueries :exec
+DELETE
+FROM alert_queries
+WHERE subscription_id = $1
+`
+
+// DeleteAlertQueries
+//
+//	DELETE
+//	FROM alert_queries
+//	WHERE subscription_id = $1
+func (q *Queries) DeleteAlertQueries(ctx context.Context, subscriptionID int64) error {
+	_, err := q.db.Exec(ctx, deleteAlertQueries, subscriptionID)
+	return err
+}
+
+const deleteAlertSubscription = `-- name: DeleteAlertSubscription :execrows
+DELETE
+FROM alert_subscriptions
+WHERE id = $1
+`
+
+// DeleteAlertSubscription
+//
+//	DELETE
+//	FROM alert_subscriptions
+//	WHERE id = $1
+func (q *Queries) DeleteAlertSubscription(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAlertSubscription, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteOldStreamAnalyses = `-- name: DeleteOldStreamAnalyses :exec
+DELETE
+FROM stream_analyses AS sa
+WHERE sa.stream_id = $1::VARCHAR
+  AND sa.id NOT IN (SELECT recent.id
+                    FROM stream_analyses AS recent
+                    WHERE recent.stream_id = $1::VARCHAR
+                    ORDER BY recent.analyzed_at DESC, recent.id DESC
+                    LIMIT $2::INTEGER)
+`
+
+type DeleteOldStreamAnalysesParams struct {
+	StreamID string
+	Keep     int32
+}
+
+// keeps only the newest analyses of the stream
+//
+//	DELETE
+//	FROM stream_analyses AS sa
+//	WHERE sa.stream_id = $1::VARCHAR
+//	  AND sa.id NOT IN (SELECT recent.id
+//	                    FROM stream_analyses AS recent
+//	                    WHERE recent.stream_id = $1::VARCHAR
+//	                    ORDER BY recent.analyzed_at DESC, recent.id DESC
+//	                    LIMIT $2::INTEGER)
+func (q *Queries) DeleteOldStreamAnalyses(ctx context.Context, arg DeleteOldStreamAnalysesParams) error {
+	_, err := q.db.Exec(ctx, deleteOldStreamAnalyses, arg.StreamID, arg.Keep)
+	return err
+}
+
+const deleteStreamNicknames = `-- name: DeleteStreamNicknames :exec
+DELETE
+FROM stream_nicknames
+WHERE stream_id = $1
+`
+
+// DeleteStreamNicknames
+//
+//	DELETE
+//	FROM stream_nicknames
+//	WHERE stream_id = $1
+func (q *Queries) DeleteStreamNicknames(ctx context.Context, streamID string) error {
+	_, err := q.db.Exec(ctx, deleteStreamNicknames, streamID)
+	return err
+}
+
+const deleteStreamerAlias = `-- name: DeleteStreamerAlias :execrows
+DELETE
+FROM streamer_aliases
+WHERE id = $1
+  AND streamer = $2
+`
+
+type DeleteStreamerAliasParams struct {
+	ID       int64
+	Streamer string
+}
+
+// DeleteStreamerAlias
+//
+//	DELETE
+//	FROM streamer_aliases
+//	WHERE id = $1
+//	  AND streamer = $2
+func (q *Queries) DeleteStreamerAlias(ctx context.Context, arg DeleteStreamerAliasParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStreamerAlias, arg.ID, arg.Streamer)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAlertChannels = `-- name: GetAlertChannels :many
+SELECT id, subscription_id, type, target, secret
+FROM alert_channels
+ORDER BY id
+`
+
+// GetAlertChannels
+//
+//	SELECT id, subscription_id, type, target, secret
+//	FROM alert_channels
+//	ORDER BY id
+func (q *Queries) GetAlertChannels(ctx context.Context) ([]AlertChannel, error) {
+	rows, err := q.db.Query(ctx, getAlertChannels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertChannel{}
+	for rows.Next() {
+		var i AlertChannel
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.Type,
+			&i.Target,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlertDeliveries = `-- name: GetAlertDeliveries :many
+SELECT id, subscription_id, channel_type, target_streamer, success, error, created
+FROM alert_deliveries
+WHERE subscription_id = $1
+ORDER BY id DESC
+LIMIT $2
+`
+
+type GetAlertDeliveriesParams struct {
+	SubscriptionID int64
+	Limit          int32
+}
+
+// GetAlertDeliveries
+//
+//	SELECT id, subscription_id, channel_type, target_streamer, success, error, created
+//	FROM alert_deliveries
+//	WHERE subscription_id = $1
+//	ORDER BY id DESC
+//	LIMIT $2
+func (q *Queries) GetAlertDeliveries(ctx context.Context, arg GetAlertDeliveriesParams) ([]AlertDelivery, error) {
+	rows, err := q.db.Query(ctx, getAlertDeliveries, arg.SubscriptionID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertDelivery{}
+	for rows.Next() {
+		var i AlertDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.ChannelType,
+			&i.TargetStreamer,
+			&i.Success,
+			&i.Error,
+			&i.Created,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlertPending = `-- name: GetAlertPending :many
+SELECT subscription_id, target_stream, last_cycle_id, history, updated
+FROM alert_pending
+WHERE subscription_id = $1
+`
+
+// GetAlertPending
+//
+//	SELECT subscription_id, target_stream, last_cycle_id, history, updated
+//	FROM alert_pending
+//	WHERE subscription_id = $1
+func (q *Queries) GetAlertPending(ctx context.Context, subscriptionID int64) ([]AlertPending, error) {
+	rows, err := q.db.Query(ctx, getAlertPending, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertPending{}
+	for rows.Next() {
+		var i AlertPending
+		if err := rows.Scan(
+			&i.SubscriptionID,
+			&i.TargetStream,
+			&i.LastCycleID,
+			&i.History,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlertQueries = `-- name: GetAlertQueries :many
+SELECT id, subscription_id, query
+FROM alert_queries
+ORDER BY id
+`
+
+// GetAlertQueries
+//
+//	SELECT id, subscription_id, query
+//	FROM alert_queries
+//	ORDER BY id
+func (q *Queries) GetAlertQueries(ctx context.Context) ([]AlertQuery, error) {
+	rows, err := q.db.Query(ctx, getAlertQueries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertQuery{}
+	for rows.Next() {
+		var i AlertQuery
+		if err := rows.Scan(&i.ID, &i.SubscriptionID, &i.Query); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlertQueriesBySubscription = `-- name: GetAlertQueriesBySubscription :many
+SELECT id, subscription_id, query
+FROM alert_queries
+WHERE subscription_id = $1
+ORDER BY id
+`
+
+// GetAlertQueriesBySubscription
+//
+//	SELECT id, subscription_id, query
+//	FROM alert_queries
+//	WHERE subscription_id = $1
+//	ORDER BY id
+func (q *Queries) GetAlertQueriesBySubscription(ctx context.Context, subscriptionID int64) ([]AlertQuery, error) {
+	rows, err := q.db.Query(ctx, getAlertQueriesBySubscription, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertQuery{}
+	for rows.Next() {
+		var i AlertQuery
+		if err := rows.Scan(&i.ID, &i.SubscriptionID, &i.Query); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlertRelevantStreamIDs = `-- name: GetAlertRelevantStreamIDs :many
+SELECT streamer AS id
+FROM alert_subscriptions
+WHERE enabled = true
+UNION
+SELECT target_stream AS id
+FROM alert_pending
+`
+
+// GetAlertRelevantStreamIDs
+//
+//	SELECT streamer AS id
+//	FROM alert_subscriptions
+//	WHERE enabled = true
+//	UNION
+//	SELECT target_stream AS id
+//	FROM alert_pending
+func (q *Queries) GetAlertRelevantStreamIDs(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, getAlertRelevantStreamIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAlertSubscription = `-- name: GetAlertSubscription :one
+SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+FROM alert_subscriptions
+WHERE id = $1
+`
+
+// GetAlertSubscription
+//
+//	SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+//	FROM alert_subscriptions
+//	WHERE id = $1
+func (q *Queries) GetAlertSubscription(ctx context.Context, id int64) (AlertSubscription, error) {
+	row := q.db.QueryRow(ctx, getAlertSubscription, id)
+	var i AlertSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Streamer,
+		&i.Enabled,
+		&i.Created,
+		&i.Updated,
+		&i.ConfirmHits,
+		&i.ConfirmWindow,
+		&i.RequireMutual,
+	)
+	return i, err
+}
+
+const getAlertSubscriptions = `-- name: GetAlertSubscriptions :many
+SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+FROM alert_subscriptions
+ORDER BY id
+`
+
+// GetAlertSubscriptions
+//
+//	SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+//	FROM alert_subscriptions
+//	ORDER BY id
+func (q *Queries) GetAlertSubscriptions(ctx context.Context) ([]AlertSubscription, error) {
+	rows, err := q.db.Query(ctx, getAlertSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertSubscription{}
+	for rows.Next() {
+		var i AlertSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Streamer,
+			&i.Enabled,
+			&i.Created,
+			&i.Updated,
+			&i.ConfirmHits,
+			&i.ConfirmWindow,
+			&i.RequireMutual,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDueStreams = `-- name: GetDueStreams :many
+SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+FROM streams
+WHERE online = true
+  AND next_scan_at <= $1::TIMESTAMP
+ORDER BY next_scan_at
+`
+
+// GetDueStreams
+//
+//	SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+//	FROM streams
+//	WHERE online = true
+//	  AND next_scan_at <= $1::TIMESTAMP
+//	ORDER BY next_scan_at
+func (q *Queries) GetDueStreams(ctx context.Context, now time.Time) ([]Stream, error) {
+	rows, err := q.db.Query(ctx, getDueStreams, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Stream{}
+	for rows.Next() {
+		var i Stream
+		if err := rows.Scan(
+			&i.ID,
+			&i.Updated,
+			&i.Url,
+			&i.Online,
+			&i.PlayerNames,
+			&i.LastCycleID,
+			&i.NextScanAt,
+			&i.ScanInterval,
+			&i.LastScanAt,
+			&i.Phase,
+			&i.Players,
+			&i.Language,
+			&i.ViewerCount,
+			&i.LastErrorCategory,
+			&i.LastErrorAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEnabledAlertSubscriptions = `-- name: GetEnabledAlertSubscriptions :many
+SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+FROM alert_subscriptions
+WHERE enabled = true
+ORDER BY id
+`
+
+// GetEnabledAlertSubscriptions
+//
+//	SELECT id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+//	FROM alert_subscriptions
+//	WHERE enabled = true
+//	ORDER BY id
+func (q *Queries) GetEnabledAlertSubscriptions(ctx context.Context) ([]AlertSubscription, error) {
+	rows, err := q.db.Query(ctx, getEnabledAlertSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertSubscription{}
+	for rows.Next() {
+		var i AlertSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Streamer,
+			&i.Enabled,
+			&i.Created,
+			&i.Updated,
+			&i.ConfirmHits,
+			&i.ConfirmWindow,
+			&i.RequireMutual,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOnlineStreams = `-- name: GetOnlineStreams :many
+SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+FROM streams
+WHERE online = true
+`
+
+// GetOnlineStreams
+//
+//	SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+//	FROM streams
+//	WHERE online = true
+func (q *Queries) GetOnlineStreams(ctx context.Context) ([]Stream, error) {
+	rows, err := q.db.Query(ctx, getOnlineStreams)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Stream{}
+	for rows.Next() {
+		var i Stream
+		if err := rows.Scan(
+			&i.ID,
+			&i.Updated,
+			&i.Url,
+			&i.Online,
+			&i.PlayerNames,
+			&i.LastCycleID,
+			&i.NextScanAt,
+			&i.ScanInterval,
+			&i.LastScanAt,
+			&i.Phase,
+			&i.Players,
+			&i.Language,
+			&i.ViewerCount,
+			&i.LastErrorCategory,
+			&i.LastErrorAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSchemaVersion = `-- name: GetSchemaVersion :one
+SELECT version
+FROM schema_version
+`
+
+// GetSchemaVersion
+//
+//	SELECT version
+//	FROM schema_version
+func (q *Queries) GetSchemaVersion(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, getSchemaVersion)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const getStream = `-- name: GetStream :one
+SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+FROM streams
+WHERE id = $1
+`
+
+// GetStream
+//
+//	SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+//	FROM streams
+//	WHERE id = $1
+func (q *Queries) GetStream(ctx context.Context, id string) (Stream, error) {
+	row := q.db.QueryRow(ctx, getStream, id)
+	var i Stream
+	err := row.Scan(
+		&i.ID,
+		&i.Updated,
+		&i.Url,
+		&i.Online,
+		&i.PlayerNames,
+		&i.LastCycleID,
+		&i.NextScanAt,
+		&i.ScanInterval,
+		&i.LastScanAt,
+		&i.Phase,
+		&i.Players,
+		&i.Language,
+		&i.ViewerCount,
+		&i.LastErrorCategory,
+		&i.LastErrorAt,
+	)
+	return i, err
+}
+
+const getStreamAnalyses = `-- name: GetStreamAnalyses :many
+SELECT id, stream_id, cycle_id, analyzed_at, phase, players, skip_reason, error_category
+FROM stream_analyses
+WHERE stream_id = $1
+ORDER BY analyzed_at DESC, id DESC
+LIMIT $2::INTEGER
+`
+
+type GetStreamAnalysesParams struct {
+	StreamID   string
+	MaxResults int32
+}
+
+// GetStreamAnalyses
+//
+//	SELECT id, stream_id, cycle_id, analyzed_at, phase, players, skip_reason, error_category
+//	FROM stream_analyses
+//	WHERE stream_id = $1
+//	ORDER BY analyzed_at DESC, id DESC
+//	LIMIT $2::INTEGER
+func (q *Queries) GetStreamAnalyses(ctx context.Context, arg GetStreamAnalysesParams) ([]StreamAnalysis, error) {
+	rows, err := q.db.Query(ctx, getStreamAnalyses, arg.StreamID, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StreamAnalysis{}
+	for rows.Next() {
+		var i StreamAnalysis
+		if err := rows.Scan(
+			&i.ID,
+			&i.StreamID,
+			&i.CycleID,
+			&i.AnalyzedAt,
+			&i.Phase,
+			&i.Players,
+			&i.SkipReason,
+			&i.ErrorCategory,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStreamSightings = `-- name: GetStreamSightings :many
+SELECT id, stream_id, nickname, normalized_nickname, confidence, observed_at, cycle_id, slot, bbox
+FROM sightings
+WHERE stream_id = $1
+  AND observed_at >= $2
+ORDER BY observed_at DESC
+`
+
+type GetStreamSightingsParams struct {
+	StreamID   string
+	ObservedAt time.Time
+}
+
+// GetStreamSightings
+//
+//	SELECT id, stream_id, nickname, normalized_nickname, confidence, observed_at, cycle_id, slot, bbox
+//	FROM sightings
+//	WHERE stream_id = $1
+//	  AND observed_at >= $2
+//	ORDER BY observed_at DESC
+func (q *Queries) GetStreamSightings(ctx context.Context, arg GetStreamSightingsParams) ([]Sighting, error) {
+	rows, err := q.db.Query(ctx, getStreamSightings, arg.StreamID, arg.ObservedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Sighting{}
+	for rows.Next() {
+		var i Sighting
+		if err := rows.Scan(
+			&i.ID,
+			&i.StreamID,
+			&i.Nickname,
+			&i.NormalizedNickname,
+			&i.Confidence,
+			&i.ObservedAt,
+			&i.CycleID,
+			&i.Slot,
+			&i.Bbox,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStreamerAliases = `-- name: GetStreamerAliases :many
+SELECT id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+FROM streamer_aliases
+WHERE streamer = $1
+ORDER BY confidence DESC, id
+`
+
+// GetStreamerAliases
+//
+//	SELECT id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+//	FROM streamer_aliases
+//	WHERE streamer = $1
+//	ORDER BY confidence DESC, id
+func (q *Queries) GetStreamerAliases(ctx context.Context, streamer string) ([]StreamerAlias, error) {
+	rows, err := q.db.Query(ctx, getStreamerAliases, streamer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StreamerAlias{}
+	for rows.Next() {
+		var i StreamerAlias
+		if err := rows.Scan(
+			&i.ID,
+			&i.Streamer,
+			&i.Nickname,
+			&i.NormalizedNickname,
+			&i.Source,
+			&i.Confidence,
+			&i.Sightings,
+			&i.LastSeen,
+			&i.Created,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStreamerNicknames = `-- name: GetStreamerNicknames :many
+SELECT s.streamer, q.query
+FROM alert_queries q
+       JOIN alert_subscriptions s ON s.id = q.subscription_id
+ORDER BY q.id
 `
 
-type CreateStreamParams struct {
-	ID      string
-	Updated time.Time
+type GetStreamerNicknamesRow struct {
+	Streamer string
+	Query    string
 }
 
-// CreateStream
+// GetStreamerNicknames
 //
-//	INSERT INTO streams(id, updated)
-//	VALUES ($1, $2) ON CONFLICT (id) DO NOTHING
-func (q *Queries) CreateStream(ctx context.Context, arg CreateStreamParams) error {
-	_, err := q.db.Exec(ctx, createStream, arg.ID, arg.Updated)
-	return err
+//	SELECT s.streamer, q.query
+//	FROM alert_queries q
+//	       JOIN alert_subscriptions s ON s.id = q.subscription_id
+//	ORDER BY q.id
+func (q *Queries) GetStreamerNicknames(ctx context.Context) ([]GetStreamerNicknamesRow, error) {
+	rows, err := q.db.Query(ctx, getStreamerNicknames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStreamerNicknamesRow{}
+	for rows.Next() {
+		var i GetStreamerNicknamesRow
+		if err := rows.Scan(&i.Streamer, &i.Query); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getOnlineStreams = `-- name: GetOnlineStreams :many
-SELECT id, updated, url, online, player_names
+const getStreamsByIDs = `-- name: GetStreamsByIDs :many
+SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
 FROM streams
-WHERE online = true
+WHERE id = ANY ($1::VARCHAR(255)[])
 `
 
-// GetOnlineStreams
+// GetStreamsByIDs
 //
-//	SELECT id, updated, url, online, player_names
+//	SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
 //	FROM streams
-//	WHERE online = true
-func (q *Queries) GetOnlineStreams(ctx context.Context) ([]Stream, error) {
-	rows, err := q.db.Query(ctx, getOnlineStreams)
+//	WHERE id = ANY ($1::VARCHAR(255)[])
+func (q *Queries) GetStreamsByIDs(ctx context.Context, ids []string) ([]Stream, error) {
+	rows, err := q.db.Query(ctx, getStreamsByIDs, ids)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +1124,16 @@ func (q *Queries) GetOnlineStreams(ctx context.Context) ([]Stream, error) {
 			&i.Url,
 			&i.Online,
 			&i.PlayerNames,
+			&i.LastCycleID,
+			&i.NextScanAt,
+			&i.ScanInterval,
+			&i.LastScanAt,
+			&i.Phase,
+			&i.Players,
+			&i.Language,
+			&i.ViewerCount,
+			&i.LastErrorCategory,
+			&i.LastErrorAt,
 		); err != nil {
 			return nil, err
 		}
@@ -66,55 +1145,507 @@ func (q *Queries) GetOnlineStreams(ctx context.Context) ([]Stream, error) {
 	return items, nil
 }
 
-const getSchemaVersion = `-- name: GetSchemaVersion :one
-SELECT version
-FROM schema_version
+const getTrustedStreamerAliases = `-- name: GetTrustedStreamerAliases :many
+SELECT id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+FROM streamer_aliases
+WHERE confidence >= $1::DOUBLE PRECISION
+ORDER BY streamer, confidence DESC, id
 `
 
-// GetSchemaVersion
+// GetTrustedStreamerAliases
 //
-//	SELECT version
-//	FROM schema_version
-func (q *Queries) GetSchemaVersion(ctx context.Context) (int32, error) {
-	row := q.db.QueryRow(ctx, getSchemaVersion)
-	var version int32
-	err := row.Scan(&version)
-	return version, err
+//	SELECT id, streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen, created, updated
+//	FROM streamer_aliases
+//	WHERE confidence >= $1::DOUBLE PRECISION
+//	ORDER BY streamer, confidence DESC, id
+func (q *Queries) GetTrustedStreamerAliases(ctx context.Context, minConfidence float64) ([]StreamerAlias, error) {
+	rows, err := q.db.Query(ctx, getTrustedStreamerAliases, minConfidence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StreamerAlias{}
+	for rows.Next() {
+		var i StreamerAlias
+		if err := rows.Scan(
+			&i.ID,
+			&i.Streamer,
+			&i.Nickname,
+			&i.NormalizedNickname,
+			&i.Source,
+			&i.Confidence,
+			&i.Sightings,
+			&i.LastSeen,
+			&i.Created,
+			&i.Updated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStreams = `-- name: ListStreams :many
+SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+FROM streams
+WHERE ($1::BOOLEAN IS NULL OR online = $1::BOOLEAN)
+  AND ($2::BOOLEAN IS NULL OR (cardinality(player_names) > 0) = $2::BOOLEAN)
+  AND ($3::TIMESTAMP IS NULL OR last_scan_at > $3::TIMESTAMP)
+  AND ($4::TIMESTAMP IS NULL OR last_scan_at < $4::TIMESTAMP)
+  AND ($5::VARCHAR IS NULL OR language = $5::VARCHAR)
+  AND ($6::INTEGER IS NULL OR viewer_count >= $6::INTEGER)
+  AND ($7::INTEGER IS NULL OR viewer_count <= $7::INTEGER)
+  AND ($8::VARCHAR IS NULL OR CASE $9::VARCHAR
+                                                   WHEN 'viewers' THEN (viewer_count, id) <
+                                                                       ($10::INTEGER, $8::VARCHAR)
+                                                   WHEN 'last_analyzed' THEN (coalesce(last_scan_at, 'epoch'), id) <
+                                                                             ($11::TIMESTAMP, $8::VARCHAR)
+                                                   ELSE id > $8::VARCHAR END)
+ORDER BY CASE WHEN $9::VARCHAR = 'viewers' THEN viewer_count END DESC,
+         CASE WHEN $9::VARCHAR = 'last_analyzed' THEN coalesce(last_scan_at, 'epoch') END DESC,
+         CASE WHEN $9::VARCHAR = 'name' THEN id END,
+         id DESC
+LIMIT $12::INTEGER
+`
+
+type ListStreamsParams struct {
+	Online         *bool
+	HasPlayers     *bool
+	AnalyzedAfter  *time.Time
+	AnalyzedBefore *time.Time
+	Language       *string
+	MinViewers     *int32
+	MaxViewers     *int32
+	CursorID       *string
+	Sort           string
+	CursorViewers  int32
+	CursorAnalyzed time.Time
+	MaxResults     int32
+}
+
+// keyset pagination, the cursor is the sort key and the id of the last stream of the previous page
+//
+//	SELECT id, updated, url, online, player_names, last_cycle_id, next_scan_at, scan_interval, last_scan_at, phase, players, language, viewer_count, last_error_category, last_error_at
+//	FROM streams
+//	WHERE ($1::BOOLEAN IS NULL OR online = $1::BOOLEAN)
+//	  AND ($2::BOOLEAN IS NULL OR (cardinality(player_names) > 0) = $2::BOOLEAN)
+//	  AND ($3::TIMESTAMP IS NULL OR last_scan_at > $3::TIMESTAMP)
+//	  AND ($4::TIMESTAMP IS NULL OR last_scan_at < $4::TIMESTAMP)
+//	  AND ($5::VARCHAR IS NULL OR language = $5::VARCHAR)
+//	  AND ($6::INTEGER IS NULL OR viewer_count >= $6::INTEGER)
+//	  AND ($7::INTEGER IS NULL OR viewer_count <= $7::INTEGER)
+//	  AND ($8::VARCHAR IS NULL OR CASE $9::VARCHAR
+//	                                                   WHEN 'viewers' THEN (viewer_count, id) <
+//	                                                                       ($10::INTEGER, $8::VARCHAR)
+//	                                                   WHEN 'last_analyzed' THEN (coalesce(last_scan_at, 'epoch'), id) <
+//	                                                                             ($11::TIMESTAMP, $8::VARCHAR)
+//	                                                   ELSE id > $8::VARCHAR END)
+//	ORDER BY CASE WHEN $9::VARCHAR = 'viewers' THEN viewer_count END DESC,
+//	         CASE WHEN $9::VARCHAR = 'last_analyzed' THEN coalesce(last_scan_at, 'epoch') END DESC,
+//	         CASE WHEN $9::VARCHAR = 'name' THEN id END,
+//	         id DESC
+//	LIMIT $12::INTEGER
+func (q *Queries) ListStreams(ctx context.Context, arg ListStreamsParams) ([]Stream, error) {
+	rows, err := q.db.Query(ctx, listStreams,
+		arg.Online,
+		arg.HasPlayers,
+		arg.AnalyzedAfter,
+		arg.AnalyzedBefore,
+		arg.Language,
+		arg.MinViewers,
+		arg.MaxViewers,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorViewers,
+		arg.CursorAnalyzed,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Stream{}
+	for rows.Next() {
+		var i Stream
+		if err := rows.Scan(
+			&i.ID,
+			&i.Updated,
+			&i.Url,
+			&i.Online,
+			&i.PlayerNames,
+			&i.LastCycleID,
+			&i.NextScanAt,
+			&i.ScanInterval,
+			&i.LastScanAt,
+			&i.Phase,
+			&i.Players,
+			&i.Language,
+			&i.ViewerCount,
+			&i.LastErrorCategory,
+			&i.LastErrorAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordStreamerAliasSighting = `-- name: RecordStreamerAliasSighting :exec
+INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen)
+VALUES ($1, $2, $3, 'auto', 1 / (1 + $4::DOUBLE PRECISION), 1,
+        $5::TIMESTAMP)
+ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+  SET sightings  = streamer_aliases.sightings + 1,
+      confidence = GREATEST(streamer_aliases.confidence,
+                            (streamer_aliases.sightings + 1) /
+                            (streamer_aliases.sightings + 1 + $4::DOUBLE PRECISION)),
+      last_seen  = EXCLUDED.last_seen,
+      updated    = CURRENT_TIMESTAMP
+WHERE streamer_aliases.last_seen IS NULL
+   OR streamer_aliases.last_seen < EXCLUDED.last_seen - make_interval(secs => $6::INTEGER)
+`
+
+type RecordStreamerAliasSightingParams struct {
+	Streamer           string
+	Nickname           string
+	NormalizedNickname string
+	Saturation         float64
+	ObservedAt         time.Time
+	SessionGap         int32
+}
+
+// counts at most one sighting per session_gap, so that a single long lobby does not inflate the confidence
+//
+//	INSERT INTO streamer_aliases(streamer, nickname, normalized_nickname, source, confidence, sightings, last_seen)
+//	VALUES ($1, $2, $3, 'auto', 1 / (1 + $4::DOUBLE PRECISION), 1,
+//	        $5::TIMESTAMP)
+//	ON CONFLICT (streamer, normalized_nickname) DO UPDATE
+//	  SET sightings  = streamer_aliases.sightings + 1,
+//	      confidence = GREATEST(streamer_aliases.confidence,
+//	                            (streamer_aliases.sightings + 1) /
+//	                            (streamer_aliases.sightings + 1 + $4::DOUBLE PRECISION)),
+//	      last_seen  = EXCLUDED.last_seen,
+//	      updated    = CURRENT_TIMESTAMP
+//	WHERE streamer_aliases.last_seen IS NULL
+//	   OR streamer_aliases.last_seen < EXCLUDED.last_seen - make_interval(secs => $6::INTEGER)
+func (q *Queries) RecordStreamerAliasSighting(ctx context.Context, arg RecordStreamerAliasSightingParams) error {
+	_, err := q.db.Exec(ctx, recordStreamerAliasSighting,
+		arg.Streamer,
+		arg.Nickname,
+		arg.NormalizedNickname,
+		arg.Saturation,
+		arg.ObservedAt,
+		arg.SessionGap,
+	)
+	return err
+}
+
+const searchSightingsByNickname = `-- name: SearchSightingsByNickname :many
+SELECT stream_id,
+       min(observed_at)::TIMESTAMP                  AS first_seen,
+       max(observed_at)::TIMESTAMP                  AS last_seen,
+       count(*)::INTEGER                            AS sightings_count,
+       array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+FROM sightings
+WHERE observed_at BETWEEN $1::TIMESTAMP AND $2::TIMESTAMP
+  AND (normalized_nickname % $3::VARCHAR(255) OR
+       normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%')
+  AND (levenshtein(normalized_nickname, $3::VARCHAR(255)) < $5::INTEGER OR
+       normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%')
+GROUP BY stream_id
+ORDER BY min(CASE
+               WHEN normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%' THEN 0
+               ELSE levenshtein(normalized_nickname, $3::VARCHAR(255)) END),
+         last_seen DESC
+LIMIT $6::INTEGER
+`
+
+type SearchSightingsByNicknameParams struct {
+	Since      time.Time
+	Until      time.Time
+	Query      string
+	Pattern    string
+	Distance   int32
+	MaxResults int32
+}
+
+type SearchSightingsByNicknameRow struct {
+	StreamID       string
+	FirstSeen      time.Time
+	LastSeen       time.Time
+	SightingsCount int32
+	Nicknames      []string
+}
+
+// candidates are prefiltered by the trigram index, the threshold is set by SetSimilarityThreshold
+//
+//	SELECT stream_id,
+//	       min(observed_at)::TIMESTAMP                  AS first_seen,
+//	       max(observed_at)::TIMESTAMP                  AS last_seen,
+//	       count(*)::INTEGER                            AS sightings_count,
+//	       array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+//	FROM sightings
+//	WHERE observed_at BETWEEN $1::TIMESTAMP AND $2::TIMESTAMP
+//	  AND (normalized_nickname % $3::VARCHAR(255) OR
+//	       normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%')
+//	  AND (levenshtein(normalized_nickname, $3::VARCHAR(255)) < $5::INTEGER OR
+//	       normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%')
+//	GROUP BY stream_id
+//	ORDER BY min(CASE
+//	               WHEN normalized_nickname LIKE '%' || $4::VARCHAR(255) || '%' THEN 0
+//	               ELSE levenshtein(normalized_nickname, $3::VARCHAR(255)) END),
+//	         last_seen DESC
+//	LIMIT $6::INTEGER
+func (q *Queries) SearchSightingsByNickname(ctx context.Context, arg SearchSightingsByNicknameParams) ([]SearchSightingsByNicknameRow, error) {
+	rows, err := q.db.Query(ctx, searchSightingsByNickname,
+		arg.Since,
+		arg.Until,
+		arg.Query,
+		arg.Pattern,
+		arg.Distance,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchSightingsByNicknameRow{}
+	for rows.Next() {
+		var i SearchSightingsByNicknameRow
+		if err := rows.Scan(
+			&i.StreamID,
+			&i.FirstSeen,
+			&i.LastSeen,
+			&i.SightingsCount,
+			&i.Nicknames,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchSightingsByNicknameExhaustive = `-- name: SearchSightingsByNicknameExhaustive :many
+SELECT stream_id,
+       min(observed_at)::TIMESTAMP                  AS first_seen,
+       max(observed_at)::TIMESTAMP                  AS last_seen,
+       count(*)::INTEGER                            AS sightings_count,
+       array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+FROM sightings
+WHERE observed_at BETWEEN $1::TIMESTAMP AND $2::TIMESTAMP
+  AND (levenshtein(normalized_nickname, $3::VARCHAR(255)) < $4::INTEGER OR
+       normalized_nickname LIKE '%' || $5::VARCHAR(255) || '%')
+GROUP BY stream_id
+ORDER BY min(CASE
+               WHEN normalized_nickname LIKE '%' || $5::VARCHAR(255) || '%' THEN 0
+               ELSE levenshtein(normalized_nickname, $3::VARCHAR(255)) END),
+         last_seen DESC
+LIMIT $6::INTEGER
+`
+
+type SearchSightingsByNicknameExhaustiveParams struct {
+	Since      time.Time
+	Until      time.Time
+	Query      string
+	Distance   int32
+	Pattern    string
+	MaxResults int32
+}
+
+type SearchSightingsByNicknameExhaustiveRow struct {
+	StreamID       string
+	FirstSeen      time.Time
+	LastSeen       time.Time
+	SightingsCount int32
+	Nicknames      []string
+}
+
+// compares the query with every nickname of the window, for the queries too short for the trigram index
+//
+//	SELECT stream_id,
+//	       min(observed_at)::TIMESTAMP                  AS first_seen,
+//	       max(observed_at)::TIMESTAMP                  AS last_seen,
+//	       count(*)::INTEGER                            AS sightings_count,
+//	       array_agg(DISTINCT nickname)::VARCHAR(255)[] AS nicknames
+//	FROM sightings
+//	WHERE observed_at BETWEEN $1::TIMESTAMP AND $2::TIMESTAMP
+//	  AND (levenshtein(normalized_nickname, $3::VARCHAR(255)) < $4::INTEGER OR
+//	       normalized_nickname LIKE '%' || $5::VARCHAR(255) || '%')
+//	GROUP BY stream_id
+//	ORDER BY min(CASE
+//	               WHEN normalized_nickname LIKE '%' || $5::VARCHAR(255) || '%' THEN 0
+//	               ELSE levenshtein(normalized_nickname, $3::VARCHAR(255)) END),
+//	         last_seen DESC
+//	LIMIT $6::INTEGER
+func (q *Queries) SearchSightingsByNicknameExhaustive(ctx context.Context, arg SearchSightingsByNicknameExhaustiveParams) ([]SearchSightingsByNicknameExhaustiveRow, error) {
+	rows, err := q.db.Query(ctx, searchSightingsByNicknameExhaustive,
+		arg.Since,
+		arg.Until,
+		arg.Query,
+		arg.Distance,
+		arg.Pattern,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchSightingsByNicknameExhaustiveRow{}
+	for rows.Next() {
+		var i SearchSightingsByNicknameExhaustiveRow
+		if err := rows.Scan(
+			&i.StreamID,
+			&i.FirstSeen,
+			&i.LastSeen,
+			&i.SightingsCount,
+			&i.Nicknames,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const searchStreamsByNickname = `-- name: SearchStreamsByNickname :many
-SELECT id, updated, url, online, player_names
+SELECT streams.id, streams.updated, streams.url, streams.online, streams.player_names, streams.last_cycle_id, streams.next_scan_at, streams.scan_interval, streams.last_scan_at, streams.phase, streams.players, streams.language, streams.viewer_count, streams.last_error_category, streams.last_error_at
 FROM streams
-WHERE online = true
-  AND EXISTS (SELECT 1
-              FROM unnest(player_names) AS nickname
-              WHERE levenshtein(lower(nickname), lower($1::VARCHAR(255))) < $2::INTEGER OR
-                                                                                          lower(nickname) LIKE '%' ||
-                                                                                                               lower($1::VARCHAR(255)) ||
-                                                                                                               '%')
-  LIMIT $3::INTEGER
+       JOIN (SELECT stream_id,
+                    min(CASE
+                          WHEN normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%' THEN 0
+                          ELSE levenshtein(normalized_nickname, $2::VARCHAR(255)) END) AS distance
+             FROM stream_nicknames
+             WHERE normalized_nickname % $2::VARCHAR(255)
+                OR normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%'
+             GROUP BY stream_id) matches ON matches.stream_id = streams.id
+WHERE streams.online = true
+  AND matches.distance < $3::INTEGER
+ORDER BY matches.distance
+LIMIT $4::INTEGER
 `
 
 type SearchStreamsByNicknameParams struct {
+	Pattern    string
 	Query      string
 	Distance   int32
 	MaxResults int32
 }
 
-// SearchStreamsByNickname
+// candidates are prefiltered by the trigram index, the threshold is set by SetSimilarityThreshold
 //
-//	SELECT id, updated, url, online, player_names
+//	SELECT streams.id, streams.updated, streams.url, streams.online, streams.player_names, streams.last_cycle_id, streams.next_scan_at, streams.scan_interval, streams.last_scan_at, streams.phase, streams.players, streams.language, streams.viewer_count, streams.last_error_category, streams.last_error_at
 //	FROM streams
-//	WHERE online = true
-//	  AND EXISTS (SELECT 1
-//	              FROM unnest(player_names) AS nickname
-//	              WHERE levenshtein(lower(nickname), lower($1::VARCHAR(255))) < $2::INTEGER OR
-//	                                                                                          lower(nickname) LIKE '%' ||
-//	                                                                                                               lower($1::VARCHAR(255)) ||
-//	                                                                                                               '%')
-//	  LIMIT $3::INTEGER
+//	       JOIN (SELECT stream_id,
+//	                    min(CASE
+//	                          WHEN normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%' THEN 0
+//	                          ELSE levenshtein(normalized_nickname, $2::VARCHAR(255)) END) AS distance
+//	             FROM stream_nicknames
+//	             WHERE normalized_nickname % $2::VARCHAR(255)
+//	                OR normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%'
+//	             GROUP BY stream_id) matches ON matches.stream_id = streams.id
+//	WHERE streams.online = true
+//	  AND matches.distance < $3::INTEGER
+//	ORDER BY matches.distance
+//	LIMIT $4::INTEGER
 func (q *Queries) SearchStreamsByNickname(ctx context.Context, arg SearchStreamsByNicknameParams) ([]Stream, error) {
-	rows, err := q.db.Query(ctx, searchStreamsByNickname, arg.Query, arg.Distance, arg.MaxResults)
+	rows, err := q.db.Query(ctx, searchStreamsByNickname,
+		arg.Pattern,
+		arg.Query,
+		arg.Distance,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Stream{}
+	for rows.Next() {
+		var i Stream
+		if err := rows.Scan(
+			&i.ID,
+			&i.Updated,
+			&i.Url,
+			&i.Online,
+			&i.PlayerNames,
+			&i.LastCycleID,
+			&i.NextScanAt,
+			&i.ScanInterval,
+			&i.LastScanAt,
+			&i.Phase,
+			&i.Players,
+			&i.Language,
+			&i.ViewerCount,
+			&i.LastErrorCategory,
+			&i.LastErrorAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchStreamsByNicknameExhaustive = `-- name: SearchStreamsByNicknameExhaustive :many
+SELECT streams.id, streams.updated, streams.url, streams.online, streams.player_names, streams.last_cycle_id, streams.next_scan_at, streams.scan_interval, streams.last_scan_at, streams.phase, streams.players, streams.language, streams.viewer_count, streams.last_error_category, streams.last_error_at
+FROM streams
+       JOIN (SELECT stream_id,
+                    min(CASE
+                          WHEN normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%' THEN 0
+                          ELSE levenshtein(normalized_nickname, $2::VARCHAR(255)) END) AS distance
+             FROM stream_nicknames
+             GROUP BY stream_id) matches ON matches.stream_id = streams.id
+WHERE streams.online = true
+  AND matches.distance < $3::INTEGER
+ORDER BY matches.distance
+LIMIT $4::INTEGER
+`
+
+type SearchStreamsByNicknameExhaustiveParams struct {
+	Pattern    string
+	Query      string
+	Distance   int32
+	MaxResults int32
+}
+
+// compares the query with every nickname, for the queries too short for the trigram index
+//
+//	SELECT streams.id, streams.updated, streams.url, streams.online, streams.player_names, streams.last_cycle_id, streams.next_scan_at, streams.scan_interval, streams.last_scan_at, streams.phase, streams.players, streams.language, streams.viewer_count, streams.last_error_category, streams.last_error_at
+//	FROM streams
+//	       JOIN (SELECT stream_id,
+//	                    min(CASE
+//	                          WHEN normalized_nickname LIKE '%' || $1::VARCHAR(255) || '%' THEN 0
+//	                          ELSE levenshtein(normalized_nickname, $2::VARCHAR(255)) END) AS distance
+//	             FROM stream_nicknames
+//	             GROUP BY stream_id) matches ON matches.stream_id = streams.id
+//	WHERE streams.online = true
+//	  AND matches.distance < $3::INTEGER
+//	ORDER BY matches.distance
+//	LIMIT $4::INTEGER
+func (q *Queries) SearchStreamsByNicknameExhaustive(ctx context.Context, arg SearchStreamsByNicknameExhaustiveParams) ([]Stream, error) {
+	rows, err := q.db.Query(ctx, searchStreamsByNicknameExhaustive,
+		arg.Pattern,
+		arg.Query,
+		arg.Distance,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +1659,16 @@ func (q *Queries) SearchStreamsByNickname(ctx context.Context, arg SearchStreams
 			&i.Url,
 			&i.Online,
 			&i.PlayerNames,
+			&i.LastCycleID,
+			&i.NextScanAt,
+			&i.ScanInterval,
+			&i.LastScanAt,
+			&i.Phase,
+			&i.Players,
+			&i.Language,
+			&i.ViewerCount,
+			&i.LastErrorCategory,
+			&i.LastErrorAt,
 		); err != nil {
 			return nil, err
 		}
@@ -153,29 +1694,131 @@ func (q *Queries) SetSchemaVersion(ctx context.Context, version int32) error {
 	return err
 }
 
+const setSimilarityThreshold = `-- name: SetSimilarityThreshold :exec
+SELECT set_config('pg_trgm.similarity_threshold', $1::TEXT, true)
+`
+
+// sets the trigram similarity used by the % operator until the end of the transaction
+//
+//	SELECT set_config('pg_trgm.similarity_threshold', $1::TEXT, true)
+func (q *Queries) SetSimilarityThreshold(ctx context.Context, threshold string) error {
+	_, err := q.db.Exec(ctx, setSimilarityThreshold, threshold)
+	return err
+}
+
+const setStreamError = `-- name: SetStreamError :exec
+UPDATE streams
+SET last_error_category = $2,
+    last_error_at       = $3
+WHERE id = $1
+`
+
+type SetStreamErrorParams struct {
+	ID                string
+	LastErrorCategory *string
+	LastErrorAt       *time.Time
+}
+
+// SetStreamError
+//
+//	UPDATE streams
+//	SET last_error_category = $2,
+//	    last_error_at       = $3
+//	WHERE id = $1
+func (q *Queries) SetStreamError(ctx context.Context, arg SetStreamErrorParams) error {
+	_, err := q.db.Exec(ctx, setStreamError, arg.ID, arg.LastErrorCategory, arg.LastErrorAt)
+	return err
+}
+
 const setStreamOnline = `-- name: SetStreamOnline :exec
 UPDATE streams
-SET online  = true,
-    updated = $2
+SET online       = true,
+    updated      = $2,
+    language     = $3,
+    viewer_count = $4
 WHERE id = $1
 `
 
 type SetStreamOnlineParams struct {
-	ID      string
-	Updated time.Time
+	ID          string
+	Updated     time.Time
+	Language    string
+	ViewerCount int32
 }
 
 // SetStreamOnline
 //
 //	UPDATE streams
-//	SET online  = true,
-//	    updated = $2
+//	SET online       = true,
+//	    updated      = $2,
+//	    language     = $3,
+//	    viewer_count = $4
 //	WHERE id = $1
 func (q *Queries) SetStreamOnline(ctx context.Context, arg SetStreamOnlineParams) error {
-	_, err := q.db.Exec(ctx, setStreamOnline, arg.ID, arg.Updated)
+	_, err := q.db.Exec(ctx, setStreamOnline,
+		arg.ID,
+		arg.Updated,
+		arg.Language,
+		arg.ViewerCount,
+	)
 	return err
 }
 
+const updateAlertSubscription = `-- name: UpdateAlertSubscription :one
+UPDATE alert_subscriptions
+SET streamer       = $2,
+    enabled        = $3,
+    confirm_hits   = $4,
+    confirm_window = $5,
+    require_mutual = $6,
+    updated        = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+`
+
+type UpdateAlertSubscriptionParams struct {
+	ID            int64
+	Streamer      string
+	Enabled       bool
+	ConfirmHits   int32
+	ConfirmWindow int32
+	RequireMutual bool
+}
+
+// UpdateAlertSubscription
+//
+//	UPDATE alert_subscriptions
+//	SET streamer       = $2,
+//	    enabled        = $3,
+//	    confirm_hits   = $4,
+//	    confirm_window = $5,
+//	    require_mutual = $6,
+//	    updated        = CURRENT_TIMESTAMP
+//	WHERE id = $1
+//	RETURNING id, streamer, enabled, created, updated, confirm_hits, confirm_window, require_mutual
+func (q *Queries) UpdateAlertSubscription(ctx context.Context, arg UpdateAlertSubscriptionParams) (AlertSubscription, error) {
+	row := q.db.QueryRow(ctx, updateAlertSubscription,
+		arg.ID,
+		arg.Streamer,
+		arg.Enabled,
+		arg.ConfirmHits,
+		arg.ConfirmWindow,
+		arg.RequireMutual,
+	)
+	var i AlertSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Streamer,
+		&i.Enabled,
+		&i.Created,
+		&i.Updated,
+		&i.ConfirmHits,
+		&i.ConfirmWindow,
+		&i.RequireMutual,
+	)
+	return i, err
+}
+
 const updateStaleStreams = `-- name: UpdateStaleStreams :exec
 UPDATE streams
 SET online = false
@@ -194,22 +1837,69 @@ func (q *Queries) UpdateStaleStreams(ctx context.Context, updated time.Time) err
 
 const updateStreamData = `-- name: UpdateStreamData :exec
 UPDATE streams
-SET player_names = $2
+SET player_names  = $2,
+    last_cycle_id = $3,
+    phase         = $4,
+    players       = $5
 WHERE id = $1
 `
 
 type UpdateStreamDataParams struct {
 	ID          string
 	PlayerNames []string
+	LastCycleID *uuid.UUID
+	Phase       string
+	Players     StreamPlayers
 }
 
 // UpdateStreamData
 //
 //	UPDATE streams
-//	SET player_names = $2
+//	SET player_names  = $2,
+//	    last_cycle_id = $3,
+//	    phase         = $4,
+//	    players       = $5
 //	WHERE id = $1
 func (q *Queries) UpdateStreamData(ctx context.Context, arg UpdateStreamDataParams) error {
-	_, err := q.db.Exec(ctx, updateStreamData, arg.ID, arg.PlayerNames)
+	_, err := q.db.Exec(ctx, updateStreamData,
+		arg.ID,
+		arg.PlayerNames,
+		arg.LastCycleID,
+		arg.Phase,
+		arg.Players,
+	)
+	return err
+}
+
+const updateStreamSchedule = `-- name: UpdateStreamSchedule :exec
+UPDATE streams
+SET next_scan_at  = $2,
+    scan_interval = $3,
+    last_scan_at  = $4
+WHERE id = $1
+`
+
+type UpdateStreamScheduleParams struct {
+	ID           string
+	NextScanAt   time.Time
+	ScanInterval int32
+	LastScanAt   *time.Time
+}
+
+// UpdateStreamSchedule
+//
+//	UPDATE streams
+//	SET next_scan_at  = $2,
+//	    scan_interval = $3,
+//	    last_scan_at  = $4
+//	WHERE id = $1
+func (q *Queries) UpdateStreamSchedule(ctx context.Context, arg UpdateStreamScheduleParams) error {
+	_, err := q.db.Exec(ctx, updateStreamSchedule,
+		arg.ID,
+		arg.NextScanAt,
+		arg.ScanInterval,
+		arg.LastScanAt,
+	)
 	return err
 }
 
@@ -233,3 +1923,37 @@ func (q *Queries) UpdateStreamUrl(ctx context.Context, arg UpdateStreamUrlParams
 	_, err := q.db.Exec(ctx, updateStreamUrl, arg.ID, arg.Url)
 	return err
 }
+
+const upsertAlertPending = `-- name: UpsertAlertPending :exec
+INSERT INTO alert_pending(subscription_id, target_stream, last_cycle_id, history, updated)
+VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+ON CONFLICT (subscription_id, target_stream) DO UPDATE
+  SET last_cycle_id = excluded.last_cycle_id,
+      history       = excluded.history,
+      updated       = excluded.updated
+`
+
+type UpsertAlertPendingParams struct {
+	SubscriptionID int64
+	TargetStream   string
+	LastCycleID    uuid.UUID
+	History        []bool
+}
+
+// UpsertAlertPending
+//
+//	INSERT INTO alert_pending(subscription_id, target_stream, last_cycle_id, history, updated)
+//	VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+//	ON CONFLICT (subscription_id, target_stream) DO UPDATE
+//	  SET last_cycle_id = excluded.last_cycle_id,
+//	      history       = excluded.history,
+//	      updated       = excluded.updated
+func (q *Queries) UpsertAlertPending(ctx context.Context, arg UpsertAlertPendingParams) error {
+	_, err := q.db.Exec(ctx, upsertAlertPending,
+		arg.SubscriptionID,
+		arg.TargetStream,
+		arg.LastCycleID,
+		arg.History,
+	)
+	return err
+}
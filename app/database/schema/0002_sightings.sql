@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS sightings
+(
+  id                  BIGSERIAL PRIMARY KEY,
+  stream_id           VARCHAR(255)     NOT NULL REFERENCES streams (id) ON DELETE CASCADE,
+  nickname            VARCHAR(255)     NOT NULL,
+  normalized_nickname VARCHAR(255)     NOT NULL,
+  confidence          DOUBLE PRECISION NOT NULL,
+  observed_at         TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  cycle_id            UUID             NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sightings_stream_id_observed_at_idx ON sightings (stream_id, observed_at);
+CREATE INDEX IF NOT EXISTS sightings_normalized_nickname_idx ON sightings (normalized_nickname);
+CREATE INDEX IF NOT EXISTS sightings_observed_at_idx ON sightings (observed_at);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS alert_subscriptions
+(
+  id       BIGSERIAL PRIMARY KEY,
+  streamer VARCHAR(255) NOT NULL UNIQUE,
+  enabled  BOOLEAN      NOT NULL DEFAULT TRUE,
+  created  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS alert_queries
+(
+  id              BIGSERIAL PRIMARY KEY,
+  subscription_id BIGINT       NOT NULL REFERENCES alert_subscriptions (id) ON DELETE CASCADE,
+  query           VARCHAR(255) NOT NULL,
+  UNIQUE (subscription_id, query)
+);
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS alert_channels
+(
+  id              BIGSERIAL PRIMARY KEY,
+  subscription_id BIGINT      NOT NULL REFERENCES alert_subscriptions (id) ON DELETE CASCADE,
+  type            VARCHAR(32) NOT NULL,
+  target          TEXT        NOT NULL DEFAULT '',
+  secret          TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS alert_deliveries
+(
+  id              BIGSERIAL PRIMARY KEY,
+  subscription_id BIGINT       NOT NULL REFERENCES alert_subscriptions (id) ON DELETE CASCADE,
+  channel_type    VARCHAR(32)  NOT NULL,
+  target_streamer VARCHAR(255) NOT NULL,
+  success         BOOLEAN      NOT NULL,
+  error           TEXT,
+  created         TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS alert_deliveries_subscription_id_idx ON alert_deliveries (subscription_id, id);
+
+-- keep notifying existing subscriptions via twitch chat
+INSERT INTO alert_channels (subscription_id, type)
+SELECT id, 'twitch'
+FROM alert_subscriptions;
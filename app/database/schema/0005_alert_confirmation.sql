@@ -0,0 +1,16 @@
+ALTER TABLE streams
+  ADD COLUMN IF NOT EXISTS last_cycle_id UUID;
+
+ALTER TABLE alert_subscriptions
+  ADD COLUMN IF NOT EXISTS confirm_hits   INTEGER NOT NULL DEFAULT 1,
+  ADD COLUMN IF NOT EXISTS confirm_window INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS alert_pending
+(
+  subscription_id BIGINT       NOT NULL REFERENCES alert_subscriptions (id) ON DELETE CASCADE,
+  target_stream   VARCHAR(255) NOT NULL,
+  last_cycle_id   UUID         NOT NULL,
+  history         BOOLEAN[]    NOT NULL DEFAULT '{}',
+  updated         TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (subscription_id, target_stream)
+);
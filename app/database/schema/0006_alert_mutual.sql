@@ -0,0 +1,2 @@
+ALTER TABLE alert_subscriptions
+  ADD COLUMN IF NOT EXISTS require_mutual BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS streamer_aliases
+(
+  id                  BIGSERIAL PRIMARY KEY,
+  streamer            VARCHAR(255)     NOT NULL,
+  nickname            VARCHAR(255)     NOT NULL,
+  normalized_nickname VARCHAR(255)     NOT NULL,
+  source              VARCHAR(16)      NOT NULL DEFAULT 'manual',
+  confidence          DOUBLE PRECISION NOT NULL DEFAULT 1,
+  sightings           INTEGER          NOT NULL DEFAULT 0,
+  last_seen           TIMESTAMP,
+  created             TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated             TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (streamer, normalized_nickname)
+);
+
+CREATE INDEX IF NOT EXISTS streamer_aliases_normalized_nickname_idx ON streamer_aliases (normalized_nickname);
+
+-- alert queries are hand-typed in-game names of the subscribed streamers
+INSERT INTO streamer_aliases (streamer, nickname, normalized_nickname, source, confidence)
+SELECT DISTINCT ON (s.streamer, q.query) s.streamer,
+                                            q.query,
+                                            lower(regexp_replace(trim(q.query), '\s+', ' ', 'g')),
+                                            'manual',
+                                            1
+FROM alert_queries q
+       JOIN alert_subscriptions s ON s.id = q.subscription_id
+ON CONFLICT DO NOTHING;
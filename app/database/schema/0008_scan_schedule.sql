@@ -0,0 +1,6 @@
+ALTER TABLE streams
+  ADD COLUMN IF NOT EXISTS next_scan_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ADD COLUMN IF NOT EXISTS scan_interval INTEGER   NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS last_scan_at  TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS streams_next_scan_at_idx ON streams (next_scan_at) WHERE online = true;
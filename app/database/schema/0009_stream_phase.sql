@@ -0,0 +1,2 @@
+ALTER TABLE streams
+  ADD COLUMN IF NOT EXISTS phase VARCHAR(16) NOT NULL DEFAULT 'unknown';
@@ -0,0 +1,6 @@
+ALTER TABLE streams
+  ADD COLUMN IF NOT EXISTS players JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE sightings
+  ADD COLUMN IF NOT EXISTS slot INTEGER,
+  ADD COLUMN IF NOT EXISTS bbox INTEGER[];
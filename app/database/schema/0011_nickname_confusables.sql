@@ -0,0 +1,27 @@
+-- mirrors util.NormalizeNickname, the two have to be kept in sync
+CREATE OR REPLACE FUNCTION normalize_nickname(nickname TEXT) RETURNS TEXT
+  LANGUAGE sql
+  IMMUTABLE
+  PARALLEL SAFE
+AS
+$$
+SELECT translate(replace(lower(btrim(regexp_replace(nickname, '\s+', ' ', 'g'))), 'rn', 'm'), 'i1|05', 'lllos')
+$$;
+
+UPDATE sightings
+SET normalized_nickname = normalize_nickname(nickname);
+
+-- aliases that become equal after the normalization are merged into the most trusted one
+DELETE
+FROM streamer_aliases
+WHERE id IN (SELECT id
+             FROM (SELECT id,
+                          row_number() OVER (
+                            PARTITION BY streamer, normalize_nickname(nickname)
+                            ORDER BY source = 'manual' DESC, sightings DESC, id
+                            ) AS rank
+                   FROM streamer_aliases) ranked
+             WHERE rank > 1);
+
+UPDATE streamer_aliases
+SET normalized_nickname = normalize_nickname(nickname);
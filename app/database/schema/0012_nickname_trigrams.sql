@@ -0,0 +1,22 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- nicknames of the latest lobby of every stream, mirrors streams.player_names so that they can be indexed
+CREATE TABLE IF NOT EXISTS stream_nicknames
+(
+  stream_id           VARCHAR(255) NOT NULL REFERENCES streams (id) ON DELETE CASCADE,
+  nickname            VARCHAR(255) NOT NULL,
+  normalized_nickname VARCHAR(255) NOT NULL,
+  PRIMARY KEY (stream_id, nickname)
+);
+
+CREATE INDEX IF NOT EXISTS stream_nicknames_normalized_nickname_trgm_idx
+  ON stream_nicknames USING gin (normalized_nickname gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS sightings_normalized_nickname_trgm_idx
+  ON sightings USING gin (normalized_nickname gin_trgm_ops);
+
+INSERT INTO stream_nicknames (stream_id, nickname, normalized_nickname)
+SELECT s.id, nickname, normalize_nickname(nickname)
+FROM streams s,
+     unnest(s.player_names) AS nickname
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+ALTER TABLE streams
+  ADD COLUMN IF NOT EXISTS language     VARCHAR(16) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS viewer_count INTEGER     NOT NULL DEFAULT 0;
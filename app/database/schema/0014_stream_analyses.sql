@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stream_analyses
+(
+  id             BIGSERIAL PRIMARY KEY,
+  stream_id      VARCHAR(255) NOT NULL REFERENCES streams (id) ON DELETE CASCADE,
+  cycle_id       UUID         NOT NULL,
+  analyzed_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  phase          VARCHAR(16)  NOT NULL DEFAULT 'unknown',
+  players        JSONB        NOT NULL DEFAULT '[]',
+  skip_reason    VARCHAR(16)  NOT NULL DEFAULT '',
+  error_category VARCHAR(32)
+);
+
+CREATE INDEX IF NOT EXISTS stream_analyses_stream_id_analyzed_at_idx ON stream_analyses (stream_id, analyzed_at DESC);
+
+ALTER TABLE streams
+  ADD COLUMN IF NOT EXISTS last_error_category VARCHAR(32),
+  ADD COLUMN IF NOT EXISTS last_error_at       TIMESTAMP;
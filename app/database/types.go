@@ -0,0 +1,16 @@
+package database
+
+// StreamPlayer is a nickname recognized on the latest frame of the stream
+type StreamPlayer struct {
+	Nickname   string  `json:"nickname"`
+	Confidence float64 `json:"confidence"`
+	// Slot is the index of the HUD row
+	Slot int `json:"slot"`
+	// Role is survivor or killer, empty if unknown
+	Role string `json:"role,omitempty"`
+	// Box is x, y, width and height of the nickname on the frame, empty if unknown
+	Box []int32 `json:"box,omitempty"`
+}
+
+// StreamPlayers is stored as JSONB in streams.players
+type StreamPlayers []StreamPlayer
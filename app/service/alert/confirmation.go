@@ -0,0 +1,139 @@
+package alert
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"slices"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/google/uuid"
+	"github.com/samber/oops"
+)
+
+// confirm records the outcome of the latest analyze cycle of every matched or pending stream
+// and returns the streams that matched in at least ConfirmHits of the last ConfirmWindow cycles.
+// Pending state is stored in the database, so it survives restarts.
+func (s *Service) confirm(ctx context.Context, entry Subscription, matches []database.Stream) ([]database.Stream, error) {
+	pendingList, err := s.queries.GetAlertPending(ctx, entry.ID)
+	if err != nil {
+		return nil, oops.Errorf("GetAlertPending: %w", err)
+	}
+
+	pendingMap := make(map[string]database.AlertPending, len(pendingList))
+	for _, pending := range pendingList {
+		pendingMap[pending.TargetStream] = pending
+	}
+
+	var confirmed []database.Stream
+
+	for _, stream := range matches {
+		cycleID := streamCycleID(stream)
+
+		pending, exists := pendingMap[stream.ID]
+		delete(pendingMap, stream.ID)
+
+		history := pending.History
+		if !exists || pending.LastCycleID != cycleID {
+			history = appendObservation(history, true, entry.ConfirmWindow)
+		}
+
+		if countHits(history) >= int(entry.ConfirmHits) {
+			if exists {
+				if err = s.queries.DeleteAlertPending(ctx, database.DeleteAlertPendingParams{
+					SubscriptionID: entry.ID,
+					TargetStream:   stream.ID,
+				}); err != nil {
+					return nil, oops.Errorf("DeleteAlertPending: %w", err)
+				}
+			}
+
+			confirmed = append(confirmed, stream)
+			continue
+		}
+
+		if err = s.queries.UpsertAlertPending(ctx, database.UpsertAlertPendingParams{
+			SubscriptionID: entry.ID,
+			TargetStream:   stream.ID,
+			LastCycleID:    cycleID,
+			History:        history,
+		}); err != nil {
+			return nil, oops.Errorf("UpsertAlertPending: %w", err)
+		}
+	}
+
+	if len(pendingMap) == 0 {
+		return confirmed, nil
+	}
+
+	// pending streams that did not match this time
+	streams, err := s.queries.GetStreamsByIDs(ctx, pie.Keys(pendingMap))
+	if err != nil {
+		return nil, oops.Errorf("GetStreamsByIDs: %w", err)
+	}
+
+	streamMap := make(map[string]database.Stream, len(streams))
+	for _, stream := range streams {
+		streamMap[stream.ID] = stream
+	}
+
+	for _, pending := range pendingMap {
+		stream, ok := streamMap[pending.TargetStream]
+
+		var history []bool
+		if ok && stream.Online {
+			history = pending.History
+
+			if cycleID := streamCycleID(stream); cycleID != pending.LastCycleID {
+				history = appendObservation(history, false, entry.ConfirmWindow)
+				pending.LastCycleID = cycleID
+			}
+		}
+
+		if !slices.Contains(history, true) {
+			if err = s.queries.DeleteAlertPending(ctx, database.DeleteAlertPendingParams{
+				SubscriptionID: entry.ID,
+				TargetStream:   pending.TargetStream,
+			}); err != nil {
+				return nil, oops.Errorf("DeleteAlertPending: %w", err)
+			}
+
+			continue
+		}
+
+		if err = s.queries.UpsertAlertPending(ctx, database.UpsertAlertPendingParams{
+			SubscriptionID: entry.ID,
+			TargetStream:   pending.TargetStream,
+			LastCycleID:    pending.LastCycleID,
+			History:        history,
+		}); err != nil {
+			return nil, oops.Errorf("UpsertAlertPending: %w", err)
+		}
+	}
+
+	return confirmed, nil
+}
+
+func streamCycleID(stream database.Stream) uuid.UUID {
+	if stream.LastCycleID == nil {
+		return uuid.Nil
+	}
+
+	return *stream.LastCycleID
+}
+
+// appendObservation appends the observation and keeps only the last window entries
+func appendObservation(history []bool, matched bool, window int32) []bool {
+	history = append(slices.Clone(history), matched)
+
+	if len(history) > int(window) {
+		history = history[len(history)-int(window):]
+	}
+
+	return history
+}
+
+func countHits(history []bool) int {
+	return len(pie.Filter(history, func(matched bool) bool {
+		return matched
+	}))
+}
@@ -0,0 +1,97 @@
+package alert
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/samber/oops"
+)
+
+// mutualMatchThreshold is the minimal mutual score that is considered a mutual match
+var mutualMatchThreshold = 0.7
+
+// getKnownNicknames returns in-game nicknames of the streamers known from their alert queries and aliases
+func (s *Service) getKnownNicknames(ctx context.Context) (map[string][]string, error) {
+	rows, err := s.queries.GetStreamerNicknames(ctx)
+	if err != nil {
+		return nil, oops.Errorf("GetStreamerNicknames: %w", err)
+	}
+
+	result, err := s.aliasService.AllNicknames(ctx)
+	if err != nil {
+		return nil, oops.Errorf("aliasService.AllNicknames: %w", err)
+	}
+
+	for _, row := range rows {
+		if !slices.Contains(result[row.Streamer], row.Query) {
+			result[row.Streamer] = append(result[row.Streamer], row.Query)
+		}
+	}
+
+	return result, nil
+}
+
+// mutualScores cross-checks every matched stream against the lobby of the alert streamer.
+// The score of a stream is the lower of the two similarities: the alert streamer's nicknames
+// in the target's lobby and the target's nicknames in the alert streamer's lobby.
+func (s *Service) mutualScores(
+	ctx context.Context,
+	entry Subscription,
+	matches []database.Stream,
+	knownNicknames map[string][]string,
+) (map[string]float64, error) {
+	result := make(map[string]float64, len(matches))
+	if len(matches) == 0 {
+		return result, nil
+	}
+
+	alertStreams, err := s.queries.GetStreamsByIDs(ctx, []string{entry.Streamer})
+	if err != nil {
+		return nil, oops.Errorf("GetStreamsByIDs: %w", err)
+	}
+
+	if len(alertStreams) == 0 || !alertStreams[0].Online {
+		return result, nil
+	}
+
+	alertLobby := alertStreams[0].PlayerNames
+
+	for _, stream := range matches {
+		// twitch login is often the same as the in-game nickname
+		targetNicknames := append([]string{stream.ID}, knownNicknames[stream.ID]...)
+
+		forward := bestSimilarity(stream.PlayerNames, knownNicknames[entry.Streamer])
+		reverse := bestSimilarity(alertLobby, targetNicknames)
+
+		result[stream.ID] = min(forward, reverse)
+	}
+
+	return result, nil
+}
+
+// bestSimilarity returns the highest similarity between any of the lobby names and any of the nicknames
+func bestSimilarity(lobby []string, nicknames []string) float64 {
+	var best float64
+
+	for _, name := range lobby {
+		for _, nickname := range nicknames {
+			best = max(best, similarity(name, nickname))
+		}
+	}
+
+	return best
+}
+
+// similarity returns 1 for equal nicknames and 0 for completely different ones,
+// OCR confusables are considered equal or close
+func similarity(a, b string) float64 {
+	maxLen := max(utf8.RuneCountInString(util.NormalizeNickname(a)), utf8.RuneCountInString(util.NormalizeNickname(b)))
+	if maxLen == 0 {
+		return 0
+	}
+
+	return 1 - util.NicknameDistance(a, b)/float64(maxLen)
+}
@@ -0,0 +1,95 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"hyperfocus/app/client/telegram"
+	"hyperfocus/app/client/twitch"
+	"hyperfocus/app/client/webhook"
+	"hyperfocus/app/database"
+	"time"
+)
+
+const (
+	ChannelTwitch   = "twitch"
+	ChannelTelegram = "telegram"
+	ChannelDiscord  = "discord"
+	ChannelWebhook  = "webhook"
+)
+
+// Notification is the alert payload that is fanned out to the subscription channels
+type Notification struct {
+	AlertStreamer  string `json:"alert_streamer"`
+	TargetStreamer string `json:"target_streamer"`
+	Message        string `json:"message"`
+	// MatchedNickname is the nickname in the target lobby that matched the alert streamer
+	MatchedNickname string `json:"matched_nickname,omitempty"`
+	// MatchScore is in (0, 1], 1 means the nickname is exactly one of the alert queries
+	MatchScore float64 `json:"match_score,omitempty"`
+	// MutualScore is in [0, 1], 1 means both streamers clearly see each other in their lobbies
+	MutualScore float64   `json:"mutual_score"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, channel database.AlertChannel, notification Notification) error
+}
+
+var _ Notifier = (*twitchNotifier)(nil)
+
+type twitchNotifier struct {
+	client *twitch.Client
+}
+
+func (n *twitchNotifier) Notify(_ context.Context, channel database.AlertChannel, notification Notification) error {
+	target := channel.Target
+	if target == "" {
+		target = notification.AlertStreamer
+	}
+
+	return n.client.SendMessage(target, notification.Message)
+}
+
+var _ Notifier = (*telegramNotifier)(nil)
+
+type telegramNotifier struct {
+	client *telegram.Client
+}
+
+func (n *telegramNotifier) Notify(ctx context.Context, channel database.AlertChannel, notification Notification) error {
+	return n.client.SendMessage(ctx, channel.Target, notification.Message)
+}
+
+var _ Notifier = (*discordNotifier)(nil)
+
+type discordNotifier struct {
+	client *webhook.Client
+}
+
+func (n *discordNotifier) Notify(ctx context.Context, channel database.AlertChannel, notification Notification) error {
+	return n.client.PostJSON(ctx, channel.Target, map[string]string{
+		"content": notification.Message,
+	}, "")
+}
+
+var _ Notifier = (*webhookNotifier)(nil)
+
+type webhookNotifier struct {
+	client *webhook.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, channel database.AlertChannel, notification Notification) error {
+	return n.client.PostJSON(ctx, channel.Target, notification, channel.Secret)
+}
+
+func (s *Service) notify(ctx context.Context, channel database.AlertChannel, notification Notification) error {
+	notifier, ok := s.notifiers[channel.Type]
+	if !ok {
+		return fmt.Errorf("unknown channel type: %s", channel.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	return notifier.Notify(ctx, channel, notification)
+}
@@ -3,9 +3,13 @@ package alert
 import (
 	"context"
 	"fmt"
+	"hyperfocus/app/client/telegram"
 	"hyperfocus/app/client/twitch"
+	"hyperfocus/app/client/webhook"
 	"hyperfocus/app/config"
 	"hyperfocus/app/database"
+	"hyperfocus/app/service/alias"
+	"hyperfocus/app/service/feed"
 	"hyperfocus/app/service/search"
 	"hyperfocus/app/util/telemetry"
 	"log/slog"
@@ -20,13 +24,17 @@ import (
 var serviceName = "alert"
 
 var notificationFormat = "@%s you might be playing vs a streamer '%s', please check"
+var mutualNotificationFormat = "@%s you are most likely playing vs a streamer '%s', they see you in their lobby too"
 
 type Service struct {
 	cfg           *config.Config
 	queries       database.TxQueries
+	transactor    database.TxTransactor
 	tracing       *telemetry.Tracing
 	searchService *search.Service
-	client        *twitch.Client
+	aliasService  *alias.Service
+	feedService   *feed.Service
+	notifiers     map[string]Notifier
 
 	alertCache *ttlcache.Cache[TriggerKey, struct{}]
 }
@@ -40,19 +48,47 @@ func New(di *do.Injector) (*Service, error) {
 	alertCache := ttlcache.New[TriggerKey, struct{}]()
 	go alertCache.Start()
 
+	webhookClient := do.MustInvoke[*webhook.Client](di)
+
+	notifiers := map[string]Notifier{
+		ChannelTwitch:   &twitchNotifier{client: do.MustInvoke[*twitch.Client](di)},
+		ChannelTelegram: &telegramNotifier{client: do.MustInvoke[*telegram.Client](di)},
+		ChannelDiscord:  &discordNotifier{client: webhookClient},
+		ChannelWebhook:  &webhookNotifier{client: webhookClient},
+	}
+
 	return &Service{
 		cfg:           do.MustInvoke[*config.Config](di),
 		queries:       do.MustInvoke[database.TxQueries](di),
+		transactor:    do.MustInvoke[database.TxTransactor](di),
 		tracing:       do.MustInvoke[*telemetry.Tracing](di),
 		searchService: do.MustInvoke[*search.Service](di),
-		client:        do.MustInvoke[*twitch.Client](di),
+		aliasService:  do.MustInvoke[*alias.Service](di),
+		feedService:   do.MustInvoke[*feed.Service](di),
+		notifiers:     notifiers,
 		alertCache:    alertCache,
 	}, nil
 }
 
 func (s *Service) doCheck(ctx context.Context) {
-	for _, entry := range s.cfg.Alert.List {
-		if err := s.checkEntry(ctx, entry); err != nil {
+	subscriptions, err := s.getEnabledSubscriptions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get alert subscriptions",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	knownNicknames, err := s.getKnownNicknames(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get known nicknames",
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	for _, entry := range subscriptions {
+		if err := s.checkEntry(ctx, entry, knownNicknames); err != nil {
 			slog.ErrorContext(ctx, "Failed to check alert entry",
 				slog.String("streamer", entry.Streamer),
 				slog.Any("error", err),
@@ -61,15 +97,46 @@ func (s *Service) doCheck(ctx context.Context) {
 	}
 }
 
-func (s *Service) checkEntry(ctx context.Context, entry config.AlertEntry) error {
-	targetStream, err := s.processQueries(ctx, entry.Streamer, entry.Queries)
+func (s *Service) checkEntry(ctx context.Context, entry Subscription, knownNicknames map[string][]string) error {
+	// known nicknames contain both the subscription queries and the trusted aliases
+	found, err := s.processQueries(ctx, entry.Streamer, knownNicknames[entry.Streamer])
 	if err != nil {
 		return fmt.Errorf("processQueries: %w", err)
 	}
-	if targetStream == "" {
-		return nil
+
+	bestMatches := make(map[string]search.Match, len(found))
+	for _, match := range found {
+		bestMatches[match.Stream.ID] = match
+	}
+
+	matches := pie.Map(found, func(match search.Match) database.Stream {
+		return match.Stream
+	})
+
+	scores, err := s.mutualScores(ctx, entry, matches, knownNicknames)
+	if err != nil {
+		return fmt.Errorf("mutualScores: %w", err)
+	}
+
+	if entry.RequireMutual {
+		matches = pie.Filter(matches, func(stream database.Stream) bool {
+			return scores[stream.ID] >= mutualMatchThreshold
+		})
+	}
+
+	confirmed, err := s.confirm(ctx, entry, matches)
+	if err != nil {
+		return fmt.Errorf("confirm: %w", err)
+	}
+
+	for _, stream := range confirmed {
+		s.alert(ctx, entry, stream.ID, bestMatches[stream.ID], scores[stream.ID])
 	}
 
+	return nil
+}
+
+func (s *Service) alert(ctx context.Context, entry Subscription, targetStream string, match search.Match, mutualScore float64) {
 	key := TriggerKey{
 		AlertSteamer:  entry.Streamer,
 		TargetSteamer: targetStream,
@@ -79,50 +146,125 @@ func (s *Service) checkEntry(ctx context.Context, entry config.AlertEntry) error
 
 	_, exists := s.alertCache.GetOrSet(key, struct{}{}, ttlcache.WithTTL[TriggerKey, struct{}](ttlDuration))
 	if exists {
-		return nil
+		return
+	}
+
+	format := notificationFormat
+	if mutualScore >= mutualMatchThreshold {
+		format = mutualNotificationFormat
 	}
 
-	notificationText := fmt.Sprintf(notificationFormat, entry.Streamer, targetStream)
+	notification := Notification{
+		AlertStreamer:   entry.Streamer,
+		TargetStreamer:  targetStream,
+		Message:         fmt.Sprintf(format, entry.Streamer, targetStream),
+		MatchedNickname: match.Nickname,
+		MatchScore:      match.Score,
+		MutualScore:     mutualScore,
+		Timestamp:       time.Now(),
+	}
 
 	if s.cfg.Alert.DryRun {
 		slog.Info("Would alert about streamsniping, but dry-run mode is enabled",
-			slog.String("message", notificationText),
+			slog.String("message", notification.Message),
+			slog.String("matched_nickname", match.Nickname),
+			slog.Float64("match_score", match.Score),
+			slog.Float64("mutual_score", mutualScore),
+			slog.Any("channel_types", channelTypes(entry.Channels)),
+			// forwards the line to the log chat, it does not depend on the alert channels
 			slog.Bool("telegram", true),
 		)
 
-		return nil
+		return
 	}
 
-	if err = s.client.SendMessage(entry.Streamer, notificationText); err != nil {
-		return fmt.Errorf("SendMessage: %w", err)
+	for _, channel := range entry.Channels {
+		s.deliver(ctx, entry, channel, notification)
 	}
 
+	s.feedService.PublishAlert(feed.Alert{
+		AlertStreamer:   notification.AlertStreamer,
+		TargetStreamer:  notification.TargetStreamer,
+		Message:         notification.Message,
+		MatchedNickname: notification.MatchedNickname,
+		MatchScore:      notification.MatchScore,
+		MutualScore:     notification.MutualScore,
+		Timestamp:       notification.Timestamp,
+	})
+
 	slog.Info("Streamsniping alert",
-		slog.String("message", notificationText),
+		slog.String("message", notification.Message),
+		slog.String("matched_nickname", match.Nickname),
+		slog.Float64("match_score", match.Score),
+		slog.Float64("mutual_score", mutualScore),
+		slog.Any("channel_types", channelTypes(entry.Channels)),
+		// forwards the line to the log chat, it does not depend on the alert channels
 		slog.Bool("telegram", true),
 	)
+}
 
-	return nil
+// channelTypes returns the types of the channels the alert is delivered to
+func channelTypes(channels []database.AlertChannel) []string {
+	return pie.Map(channels, func(channel database.AlertChannel) string {
+		return channel.Type
+	})
 }
 
-func (s *Service) processQueries(ctx context.Context, alertStreamer string, queries []string) (string, error) {
+func (s *Service) deliver(ctx context.Context, entry Subscription, channel database.AlertChannel, notification Notification) {
+	var errStr *string
+
+	notifyErr := s.notify(ctx, channel, notification)
+	if notifyErr != nil {
+		slog.ErrorContext(ctx, "Failed to deliver alert",
+			slog.String("streamer", entry.Streamer),
+			slog.String("channel_type", channel.Type),
+			slog.Any("error", notifyErr),
+		)
+
+		errMsg := notifyErr.Error()
+		errStr = &errMsg
+	}
+
+	if err := s.queries.CreateAlertDelivery(ctx, database.CreateAlertDeliveryParams{
+		SubscriptionID: entry.ID,
+		ChannelType:    channel.Type,
+		TargetStreamer: notification.TargetStreamer,
+		Success:        notifyErr == nil,
+		Error:          errStr,
+	}); err != nil {
+		slog.ErrorContext(ctx, "Failed to record alert delivery",
+			slog.String("streamer", entry.Streamer),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// processQueries returns the best match of every online stream that matches any of the queries, best first
+func (s *Service) processQueries(ctx context.Context, alertStreamer string, queries []string) ([]search.Match, error) {
+	var result []search.Match
+
 	for _, query := range queries {
 		searchResults, err := s.searchService.Search(ctx, query)
 		if err != nil {
-			return "", fmt.Errorf("searchService.Search(%s): %w", query, err)
+			return nil, fmt.Errorf("searchService.Search(%s): %w", query, err)
 		}
 
-		// ignore the streamer themselves
-		searchResults = pie.Filter(searchResults, func(stream database.Stream) bool {
-			return stream.ID != alertStreamer
-		})
+		for _, match := range searchResults {
+			// ignore the streamer themselves
+			if match.Stream.ID == alertStreamer || !match.Stream.Online {
+				continue
+			}
+
+			// weak matches like short substrings of longer nicknames are shown by the search, but are too noisy to alert
+			if match.Score < s.cfg.Search.MinAlertScore {
+				continue
+			}
 
-		if len(searchResults) > 0 {
-			return searchResults[0].ID, nil
+			result = append(result, match)
 		}
 	}
 
-	return "", nil
+	return search.BestMatches(result), nil
 }
 
 func (s *Service) RunFetchLoop(ctx context.Context) {
@@ -0,0 +1,326 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"hyperfocus/app/database"
+	"net/http"
+	"strings"
+
+	"github.com/elliotchance/pie/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/oops"
+)
+
+type Subscription struct {
+	database.AlertSubscription
+	Queries  []string
+	Channels []database.AlertChannel
+}
+
+type ChannelParams struct {
+	Type   string
+	Target string
+	Secret string
+}
+
+type SubscriptionParams struct {
+	Streamer string
+	Enabled  bool
+	Queries  []string
+	Channels []ChannelParams
+	// ConfirmHits is the number of analyze cycles out of the last ConfirmWindow ones
+	// in which the match has to be seen before alerting
+	ConfirmHits   int32
+	ConfirmWindow int32
+	// RequireMutual only alerts when the target stream is seen in the lobby of the alert streamer as well
+	RequireMutual bool
+}
+
+var errSubscriptionNotFound = oops.
+	With("status_code", http.StatusNotFound).
+	Public("alert subscription not found").
+	New("alert subscription not found")
+
+var errSubscriptionExists = oops.
+	With("status_code", http.StatusBadRequest).
+	Public("alert subscription for this streamer already exists").
+	New("alert subscription already exists")
+
+func (s *Service) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "list_subscriptions")
+	defer span.End()
+
+	subscriptions, err := s.queries.GetAlertSubscriptions(ctx)
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("GetAlertSubscriptions: %w", err))
+	}
+
+	result, err := s.attachDetails(ctx, subscriptions)
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("attachDetails: %w", err))
+	}
+
+	s.tracing.Success(span)
+
+	return result, nil
+}
+
+func (s *Service) CreateSubscription(ctx context.Context, params SubscriptionParams) (*Subscription, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "create_subscription")
+	defer span.End()
+
+	if err := validateParams(params); err != nil {
+		return nil, s.tracing.Error(span, err)
+	}
+
+	var result *Subscription
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context, _ pgx.Tx, qtx database.TxQueries) error {
+		subscription, err := qtx.CreateAlertSubscription(ctx, database.CreateAlertSubscriptionParams{
+			Streamer:      strings.ToLower(params.Streamer),
+			Enabled:       params.Enabled,
+			ConfirmHits:   params.ConfirmHits,
+			ConfirmWindow: params.ConfirmWindow,
+			RequireMutual: params.RequireMutual,
+		})
+		if err != nil {
+			if isUniqueViolation(err) {
+				return errSubscriptionExists
+			}
+
+			return oops.Errorf("CreateAlertSubscription: %w", err)
+		}
+
+		result, err = s.replaceDetails(ctx, qtx, subscription, params)
+		if err != nil {
+			return oops.Errorf("replaceDetails: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("transactor.Transaction: %w", err))
+	}
+
+	s.tracing.Success(span)
+
+	return result, nil
+}
+
+func (s *Service) UpdateSubscription(ctx context.Context, id int64, params SubscriptionParams) (*Subscription, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "update_subscription")
+	defer span.End()
+
+	if err := validateParams(params); err != nil {
+		return nil, s.tracing.Error(span, err)
+	}
+
+	var result *Subscription
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context, _ pgx.Tx, qtx database.TxQueries) error {
+		subscription, err := qtx.UpdateAlertSubscription(ctx, database.UpdateAlertSubscriptionParams{
+			ID:            id,
+			Streamer:      strings.ToLower(params.Streamer),
+			Enabled:       params.Enabled,
+			ConfirmHits:   params.ConfirmHits,
+			ConfirmWindow: params.ConfirmWindow,
+			RequireMutual: params.RequireMutual,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errSubscriptionNotFound
+			}
+			if isUniqueViolation(err) {
+				return errSubscriptionExists
+			}
+
+			return oops.Errorf("UpdateAlertSubscription: %w", err)
+		}
+
+		if err = qtx.DeleteAlertQueries(ctx, id); err != nil {
+			return oops.Errorf("DeleteAlertQueries: %w", err)
+		}
+
+		if err = qtx.DeleteAlertChannels(ctx, id); err != nil {
+			return oops.Errorf("DeleteAlertChannels: %w", err)
+		}
+
+		result, err = s.replaceDetails(ctx, qtx, subscription, params)
+		if err != nil {
+			return oops.Errorf("replaceDetails: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("transactor.Transaction: %w", err))
+	}
+
+	s.tracing.Success(span)
+
+	return result, nil
+}
+
+func (s *Service) DeleteSubscription(ctx context.Context, id int64) error {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "delete_subscription")
+	defer span.End()
+
+	count, err := s.queries.DeleteAlertSubscription(ctx, id)
+	if err != nil {
+		return s.tracing.Error(span, oops.Errorf("DeleteAlertSubscription: %w", err))
+	}
+	if count == 0 {
+		return s.tracing.Error(span, errSubscriptionNotFound)
+	}
+
+	s.tracing.Success(span)
+
+	return nil
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, id int64, limit int32) ([]database.AlertDelivery, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "list_deliveries")
+	defer span.End()
+
+	if _, err := s.queries.GetAlertSubscription(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.tracing.Error(span, errSubscriptionNotFound)
+		}
+
+		return nil, s.tracing.Error(span, oops.Errorf("GetAlertSubscription: %w", err))
+	}
+
+	deliveries, err := s.queries.GetAlertDeliveries(ctx, database.GetAlertDeliveriesParams{
+		SubscriptionID: id,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("GetAlertDeliveries: %w", err))
+	}
+
+	s.tracing.Success(span)
+
+	return deliveries, nil
+}
+
+func (s *Service) getEnabledSubscriptions(ctx context.Context) ([]Subscription, error) {
+	subscriptions, err := s.queries.GetEnabledAlertSubscriptions(ctx)
+	if err != nil {
+		return nil, oops.Errorf("GetEnabledAlertSubscriptions: %w", err)
+	}
+
+	return s.attachDetails(ctx, subscriptions)
+}
+
+func (s *Service) attachDetails(ctx context.Context, subscriptions []database.AlertSubscription) ([]Subscription, error) {
+	queries, err := s.queries.GetAlertQueries(ctx)
+	if err != nil {
+		return nil, oops.Errorf("GetAlertQueries: %w", err)
+	}
+
+	channels, err := s.queries.GetAlertChannels(ctx)
+	if err != nil {
+		return nil, oops.Errorf("GetAlertChannels: %w", err)
+	}
+
+	queryMap := make(map[int64][]string)
+	for _, query := range queries {
+		queryMap[query.SubscriptionID] = append(queryMap[query.SubscriptionID], query.Query)
+	}
+
+	channelMap := make(map[int64][]database.AlertChannel)
+	for _, channel := range channels {
+		channelMap[channel.SubscriptionID] = append(channelMap[channel.SubscriptionID], channel)
+	}
+
+	return pie.Map(subscriptions, func(subscription database.AlertSubscription) Subscription {
+		return Subscription{
+			AlertSubscription: subscription,
+			Queries:           queryMap[subscription.ID],
+			Channels:          channelMap[subscription.ID],
+		}
+	}), nil
+}
+
+func (s *Service) replaceDetails(
+	ctx context.Context,
+	qtx database.TxQueries,
+	subscription database.AlertSubscription,
+	params SubscriptionParams,
+) (*Subscription, error) {
+	queries := make([]string, 0, len(params.Queries))
+
+	for _, query := range params.Queries {
+		query = strings.TrimSpace(query)
+		if query == "" || pie.Contains(queries, query) {
+			continue
+		}
+
+		queries = append(queries, query)
+
+		if err := qtx.CreateAlertQuery(ctx, database.CreateAlertQueryParams{
+			SubscriptionID: subscription.ID,
+			Query:          query,
+		}); err != nil {
+			return nil, oops.Errorf("CreateAlertQuery: %w", err)
+		}
+	}
+
+	channelParams := params.Channels
+	if len(channelParams) == 0 {
+		channelParams = []ChannelParams{{Type: ChannelTwitch}}
+	}
+
+	channels := make([]database.AlertChannel, 0, len(channelParams))
+
+	for _, channel := range channelParams {
+		if _, ok := s.notifiers[channel.Type]; !ok {
+			return nil, oops.
+				With("status_code", http.StatusBadRequest).
+				Public("unknown channel type").
+				Errorf("unknown channel type: %s", channel.Type)
+		}
+		if channel.Type != ChannelTwitch && channel.Target == "" {
+			return nil, oops.
+				With("status_code", http.StatusBadRequest).
+				Public("channel target is required").
+				Errorf("empty target for channel type %s", channel.Type)
+		}
+
+		created, err := qtx.CreateAlertChannel(ctx, database.CreateAlertChannelParams{
+			SubscriptionID: subscription.ID,
+			Type:           channel.Type,
+			Target:         channel.Target,
+			Secret:         channel.Secret,
+		})
+		if err != nil {
+			return nil, oops.Errorf("CreateAlertChannel: %w", err)
+		}
+
+		channels = append(channels, created)
+	}
+
+	return &Subscription{
+		AlertSubscription: subscription,
+		Queries:           queries,
+		Channels:          channels,
+	}, nil
+}
+
+func validateParams(params SubscriptionParams) error {
+	if params.ConfirmHits < 1 || params.ConfirmWindow < params.ConfirmHits {
+		return oops.
+			With("status_code", http.StatusBadRequest).
+			Public("confirm hits must be positive and not greater than confirm window").
+			Errorf("invalid confirmation rule: %d of %d", params.ConfirmHits, params.ConfirmWindow)
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,198 @@
+package alias
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util"
+	"hyperfocus/app/util/dbd"
+	"hyperfocus/app/util/telemetry"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var serviceName = "alias"
+
+const (
+	SourceManual = "manual"
+	SourceAuto   = "auto"
+)
+
+// minTrustedConfidence is the confidence starting from which the alias is used by search and alerts
+var minTrustedConfidence = 0.5
+
+// confidenceSaturation controls how fast the confidence of auto aliases grows:
+// confidence = sightings / (sightings + confidenceSaturation)
+var confidenceSaturation = 5.0
+
+// sessionGap is the minimal time between two counted sightings of the same alias
+var sessionGap = 30 * time.Minute
+
+var errAliasNotFound = oops.
+	With("status_code", http.StatusNotFound).
+	Public("streamer alias not found").
+	New("streamer alias not found")
+
+var errEmptyNickname = oops.
+	With("status_code", http.StatusBadRequest).
+	Public("nickname must not be empty").
+	New("nickname must not be empty")
+
+type Service struct {
+	queries database.TxQueries
+	tracing *telemetry.Tracing
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		queries: do.MustInvoke[database.TxQueries](di),
+		tracing: do.MustInvoke[*telemetry.Tracing](di),
+	}, nil
+}
+
+func (s *Service) List(ctx context.Context, streamer string) ([]database.StreamerAlias, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "list")
+	defer span.End()
+
+	data, err := s.queries.GetStreamerAliases(ctx, strings.ToLower(streamer))
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("GetStreamerAliases: %w", err))
+	}
+
+	s.tracing.Success(span)
+
+	return data, nil
+}
+
+// Create adds a manual alias or promotes an existing auto alias to a manual one
+func (s *Service) Create(ctx context.Context, streamer, nickname string) (*database.StreamerAlias, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "create")
+	defer span.End()
+
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" {
+		return nil, s.tracing.Error(span, errEmptyNickname)
+	}
+
+	data, err := s.queries.CreateStreamerAlias(ctx, database.CreateStreamerAliasParams{
+		Streamer:           strings.ToLower(streamer),
+		Nickname:           nickname,
+		NormalizedNickname: util.NormalizeNickname(nickname),
+	})
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("CreateStreamerAlias: %w", err))
+	}
+
+	s.tracing.Success(span)
+
+	return &data, nil
+}
+
+func (s *Service) Delete(ctx context.Context, streamer string, id int64) error {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "delete")
+	defer span.End()
+
+	affected, err := s.queries.DeleteStreamerAlias(ctx, database.DeleteStreamerAliasParams{
+		ID:       id,
+		Streamer: strings.ToLower(streamer),
+	})
+	if err != nil {
+		return s.tracing.Error(span, oops.Errorf("DeleteStreamerAlias: %w", err))
+	}
+
+	if affected == 0 {
+		return s.tracing.Error(span, errAliasNotFound)
+	}
+
+	s.tracing.Success(span)
+
+	return nil
+}
+
+// Nicknames returns trusted in-game nicknames of the streamer
+func (s *Service) Nicknames(ctx context.Context, streamer string) ([]string, error) {
+	aliases, err := s.List(ctx, streamer)
+	if err != nil {
+		return nil, oops.Errorf("List: %w", err)
+	}
+
+	var result []string
+
+	for _, alias := range aliases {
+		if alias.Confidence >= minTrustedConfidence {
+			result = append(result, alias.Nickname)
+		}
+	}
+
+	return result, nil
+}
+
+// AllNicknames returns trusted in-game nicknames of all known streamers
+func (s *Service) AllNicknames(ctx context.Context) (map[string][]string, error) {
+	aliases, err := s.queries.GetTrustedStreamerAliases(ctx, minTrustedConfidence)
+	if err != nil {
+		return nil, oops.Errorf("GetTrustedStreamerAliases: %w", err)
+	}
+
+	result := make(map[string][]string)
+	for _, alias := range aliases {
+		result[alias.Streamer] = append(result[alias.Streamer], alias.Nickname)
+	}
+
+	return result, nil
+}
+
+// RecordSightings grows the confidence of the streamer's own nickname seen on the streamer's own stream.
+// Only the first row of the in-trial HUD is counted: it always holds the player the stream belongs to,
+// while the rest of the lobby are teammates who must not become aliases however often they play together.
+// It accepts queries so that it can be a part of the caller's transaction.
+func (s *Service) RecordSightings(
+	ctx context.Context,
+	queries database.Querier,
+	streamer string,
+	phase dbd.Phase,
+	nicknames []dbd.Nickname,
+	observedAt time.Time,
+) error {
+	nickname, ok := ownNickname(phase, nicknames)
+	if !ok {
+		return nil
+	}
+
+	normalized := util.NormalizeNickname(nickname)
+	if normalized == "" {
+		return nil
+	}
+
+	if err := queries.RecordStreamerAliasSighting(ctx, database.RecordStreamerAliasSightingParams{
+		Streamer:           strings.ToLower(streamer),
+		Nickname:           nickname,
+		NormalizedNickname: normalized,
+		Saturation:         confidenceSaturation,
+		ObservedAt:         observedAt,
+		SessionGap:         int32(sessionGap.Seconds()),
+	}); err != nil {
+		return oops.Errorf("RecordStreamerAliasSighting: %w", err)
+	}
+
+	return nil
+}
+
+// ownNickname returns the nickname of the player the stream belongs to.
+// The lobby and the scoreboard don't put the player in a fixed row, so only the in-trial HUD is used.
+func ownNickname(phase dbd.Phase, nicknames []dbd.Nickname) (string, bool) {
+	if phase != dbd.PhaseTrial {
+		return "", false
+	}
+
+	for _, nickname := range nicknames {
+		if nickname.Slot == 0 {
+			return nickname.Text, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,87 @@
+package alias
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util"
+	"hyperfocus/app/util/dbd"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sightingQuerier applies RecordStreamerAliasSighting the way the query does
+type sightingQuerier struct {
+	database.Querier
+	aliases map[string]*database.StreamerAlias
+}
+
+func (q *sightingQuerier) RecordStreamerAliasSighting(_ context.Context, arg database.RecordStreamerAliasSightingParams) error {
+	alias, ok := q.aliases[arg.NormalizedNickname]
+	if !ok {
+		q.aliases[arg.NormalizedNickname] = &database.StreamerAlias{
+			Nickname:   arg.Nickname,
+			Confidence: 1 / (1 + arg.Saturation),
+			Sightings:  1,
+			LastSeen:   &arg.ObservedAt,
+		}
+		return nil
+	}
+
+	if !alias.LastSeen.Before(arg.ObservedAt.Add(-time.Duration(arg.SessionGap) * time.Second)) {
+		return nil
+	}
+
+	alias.Sightings++
+	alias.Confidence = max(alias.Confidence, float64(alias.Sightings)/(float64(alias.Sightings)+arg.Saturation))
+	alias.LastSeen = &arg.ObservedAt
+
+	return nil
+}
+
+func TestRecordSightings_OnlyOwnNickname(t *testing.T) {
+	service := &Service{}
+	queries := &sightingQuerier{aliases: make(map[string]*database.StreamerAlias)}
+
+	started := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	// the duo partner plays every session with the streamer
+	for session := range 20 {
+		observedAt := started.Add(time.Duration(session) * 24 * time.Hour)
+
+		require.NoError(t, service.RecordSightings(context.Background(), queries, "SunnieLemonDrop", dbd.PhaseTrial,
+			[]dbd.Nickname{
+				{Text: "SunnieLemonDrop", Slot: 0},
+				{Text: "Katt", Slot: 1},
+				{Text: "eroixks", Slot: 2},
+			}, observedAt))
+
+		require.NoError(t, service.RecordSightings(context.Background(), queries, "SunnieLemonDrop", dbd.PhaseLobby,
+			[]dbd.Nickname{
+				{Text: "Katt", Slot: 0},
+				{Text: "SunnieLemonDrop", Slot: 1},
+			}, observedAt.Add(time.Hour)))
+	}
+
+	require.Contains(t, queries.aliases, util.NormalizeNickname("SunnieLemonDrop"))
+	assert.GreaterOrEqual(t, queries.aliases[util.NormalizeNickname("SunnieLemonDrop")].Confidence, minTrustedConfidence)
+
+	assert.NotContains(t, queries.aliases, util.NormalizeNickname("Katt"))
+	assert.NotContains(t, queries.aliases, util.NormalizeNickname("eroixks"))
+}
+
+func TestRecordSightings_NoOwnRow(t *testing.T) {
+	service := &Service{}
+	queries := &sightingQuerier{aliases: make(map[string]*database.StreamerAlias)}
+
+	// the first HUD row was not recognized
+	require.NoError(t, service.RecordSightings(context.Background(), queries, "k0per1s", dbd.PhaseTrial,
+		[]dbd.Nickname{
+			{Text: "PkNoLuck", Slot: 1},
+			{Text: "livia", Slot: 2},
+		}, time.Now()))
+
+	assert.Empty(t, queries.aliases)
+}
@@ -5,30 +5,49 @@ import (
 	"errors"
 	"hyperfocus/app/client/twitch_live"
 	"hyperfocus/app/database"
+	"hyperfocus/app/util/dbd"
 	"image"
+	"log/slog"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/samber/oops"
 )
 
 var ErrNoOptimalStreamQuality = errors.New("no optimal stream quality")
 
 type StreamTask struct {
-	Index  int
-	Stream database.Stream
-
-	Mutex sync.Mutex
-	Frame image.Image
-	Error bool
+	Index   int
+	Stream  database.Stream
+	CycleID uuid.UUID
+	// Relevant is set for streams watched by alert subscriptions
+	Relevant bool
+	// Session is set for streams whose frames are read from a long-lived frame session
+	Session bool
+
+	Mutex     sync.Mutex
+	Frame     image.Image
+	FrameTime time.Time
+	// Err is the fetch error, nil if the frame was fetched or the stream is offline
+	Err error
 }
 
-func (s *Service) obtainStreamFrame(ctx context.Context, stream database.Stream, proxy string) (image.Image, error) {
+func (s *Service) obtainStreamFrame(ctx context.Context, stream database.Stream, proxy string, session bool) (image.Image, error) {
+	if session {
+		maxAge := 2 * time.Duration(s.cfg.Processing.Sessions.FrameInterval) * time.Second
+		if frameImg, ok := s.frameGrabber.LatestFrame(stream.ID, maxAge); ok {
+			return frameImg, nil
+		}
+	}
+
 	// try to use cached stream url first
 	if stream.Url != nil {
-		frameImg, err := s.frameGrabber.GrabFrameFromM3U8(ctx, *stream.Url, proxy)
+		frameImg, err := s.frameGrabber.GrabFrameFromM3U8(ctx, *stream.Url)
 		if err == nil {
+			s.openSession(stream.ID, *stream.Url, session)
 			return frameImg, nil
 		}
 	}
@@ -43,10 +62,10 @@ func (s *Service) obtainStreamFrame(ctx context.Context, stream database.Stream,
 			return nil, nil
 		}
 
-		return nil, oops.Errorf("GetM3U8: %w", err)
+		return nil, withCategory(categoryPlaylist, oops.Errorf("GetM3U8: %w", err))
 	}
 	if len(streamQualities) == 0 {
-		return nil, oops.Errorf("No stream qualities found")
+		return nil, withCategory(categoryQuality, oops.Errorf("No stream qualities found"))
 	}
 
 	quality, err := selectOptimalStreamQuality(streamQualities)
@@ -56,9 +75,9 @@ func (s *Service) obtainStreamFrame(ctx context.Context, stream database.Stream,
 
 	url := quality.URL
 
-	frameImg, err := s.frameGrabber.GrabFrameFromM3U8(ctx, url, proxy)
+	frameImg, err := s.frameGrabber.GrabFrameFromM3U8(ctx, url)
 	if err != nil {
-		return nil, oops.Errorf("GrabFrameFromM3U8: %w", err)
+		return nil, withCategory(categoryFrame, oops.Errorf("GrabFrameFromM3U8: %w", err))
 	}
 
 	// cache stream url
@@ -66,12 +85,28 @@ func (s *Service) obtainStreamFrame(ctx context.Context, stream database.Stream,
 		ID:  stream.ID,
 		Url: &url,
 	}); err != nil {
-		return nil, oops.Errorf("UpdateStreamUrl: %w", err)
+		return nil, withCategory(categoryDatabase, oops.Errorf("UpdateStreamUrl: %w", err))
 	}
 
+	s.openSession(stream.ID, url, session)
+
 	return frameImg, err
 }
 
+// openSession starts a frame session so that the next cycles don't have to fetch the frame from scratch
+func (s *Service) openSession(streamID, url string, session bool) {
+	if !session || s.frameGrabber.HasSession(streamID) {
+		return
+	}
+
+	if err := s.frameGrabber.OpenSession(streamID, url); err != nil {
+		slog.Debug("Failed to open frame session",
+			slog.String("channel_name", streamID),
+			slog.Any("error", err),
+		)
+	}
+}
+
 func selectOptimalStreamQuality(arr []twitch_live.StreamQuality) (twitch_live.StreamQuality, error) {
 	var result twitch_live.StreamQuality
 	var maxResolution int
@@ -109,3 +144,29 @@ func selectOptimalStreamQuality(arr []twitch_live.StreamQuality) (twitch_live.St
 
 	return result, nil
 }
+
+// mapPlayers converts recognized nicknames into the stream's latest players
+func mapPlayers(nicknames []dbd.Nickname) database.StreamPlayers {
+	result := make(database.StreamPlayers, 0, len(nicknames))
+
+	for _, nickname := range nicknames {
+		result = append(result, database.StreamPlayer{
+			Nickname:   nickname.Text,
+			Confidence: nickname.Confidence,
+			Slot:       nickname.Slot,
+			Role:       string(nickname.Role),
+			Box:        boxValues(nickname.Box),
+		})
+	}
+
+	return result
+}
+
+// boxValues returns x, y, width and height of the box, nil if the box is empty
+func boxValues(box image.Rectangle) []int32 {
+	if box.Empty() {
+		return nil
+	}
+
+	return []int32{int32(box.Min.X), int32(box.Min.Y), int32(box.Dx()), int32(box.Dy())}
+}
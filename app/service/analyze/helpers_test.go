@@ -17,7 +17,7 @@ func TestSelectStreamQuality(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, client)
 
-	qualities, err := client.GetM3U8(context.Background(), "poltos_tv")
+	qualities, err := client.GetM3U8(context.Background(), "poltos_tv", "")
 	require.NoError(t, err)
 	require.NotNil(t, qualities)
 
@@ -0,0 +1,92 @@
+package analyze
+
+import (
+	"context"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util"
+	"hyperfocus/app/util/dbd"
+	"slices"
+	"time"
+
+	"github.com/samber/oops"
+)
+
+// fastScanInterval is used for alert-relevant streams and streams whose lobby just changed
+var fastScanInterval = 15 * time.Second
+
+// matchScanInterval is used for streams that are in a match
+var matchScanInterval = 30 * time.Second
+
+// defaultScanInterval is the starting point of the back off
+var defaultScanInterval = 60 * time.Second
+
+// maxScanInterval caps the back off of streams that show menus, non-game content or fail
+var maxScanInterval = 10 * time.Minute
+
+// nextScanInterval decides how soon the stream should be scanned again,
+// result is nil if the frame could not be fetched or analyzed
+func nextScanInterval(task *StreamTask, result *dbd.AnalyzeResult) time.Duration {
+	prev := time.Duration(task.Stream.ScanInterval) * time.Second
+
+	var interval time.Duration
+
+	switch {
+	case result == nil || !result.Phase.InGame():
+		interval = min(max(2*prev, defaultScanInterval), maxScanInterval)
+	case playersChanged(task.Stream.PlayerNames, result.Usernames):
+		interval = fastScanInterval
+	default:
+		interval = matchScanInterval
+	}
+
+	if task.Relevant {
+		interval = min(interval, fastScanInterval)
+	}
+
+	return interval
+}
+
+func playersChanged(prev, current []string) bool {
+	normalize := func(names []string) []string {
+		result := make([]string, 0, len(names))
+		for _, name := range names {
+			result = append(result, util.NormalizeNickname(name))
+		}
+		slices.Sort(result)
+
+		return result
+	}
+
+	return !slices.Equal(normalize(prev), normalize(current))
+}
+
+func (s *Service) updateSchedule(ctx context.Context, task *StreamTask, result *dbd.AnalyzeResult) error {
+	interval := nextScanInterval(task, result)
+	now := time.Now()
+
+	if err := s.queries.UpdateStreamSchedule(ctx, database.UpdateStreamScheduleParams{
+		ID:           task.Stream.ID,
+		NextScanAt:   now.Add(interval),
+		ScanInterval: int32(interval.Seconds()),
+		LastScanAt:   &now,
+	}); err != nil {
+		return oops.Errorf("UpdateStreamSchedule: %w", err)
+	}
+
+	return nil
+}
+
+// getAlertRelevantStreams returns streams that are watched by alert subscriptions
+func (s *Service) getAlertRelevantStreams(ctx context.Context) (map[string]struct{}, error) {
+	ids, err := s.queries.GetAlertRelevantStreamIDs(ctx)
+	if err != nil {
+		return nil, oops.Errorf("GetAlertRelevantStreamIDs: %w", err)
+	}
+
+	result := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		result[id] = struct{}{}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package analyze
+
+import (
+	"hyperfocus/app/database"
+	"hyperfocus/app/util/dbd"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextScanInterval(t *testing.T) {
+	lobby := []string{"Alice", "Bob", "Carol", "Dave"}
+
+	tests := []struct {
+		name     string
+		prev     time.Duration
+		players  []string
+		relevant bool
+		result   *dbd.AnalyzeResult
+		want     time.Duration
+	}{
+		{
+			name:   "failed scan starts back off",
+			result: nil,
+			want:   defaultScanInterval,
+		},
+		{
+			name:   "menus double the interval",
+			prev:   2 * time.Minute,
+			result: &dbd.AnalyzeResult{Phase: dbd.PhaseMenu},
+			want:   4 * time.Minute,
+		},
+		{
+			name:   "back off is capped",
+			prev:   maxScanInterval,
+			result: &dbd.AnalyzeResult{Phase: dbd.PhaseUnknown},
+			want:   maxScanInterval,
+		},
+		{
+			name:    "changed lobby",
+			prev:    5 * time.Minute,
+			players: []string{"Alice"},
+			result:  &dbd.AnalyzeResult{Phase: dbd.PhaseTrial, Usernames: lobby},
+			want:    fastScanInterval,
+		},
+		{
+			name:    "same lobby ignoring order and case",
+			players: []string{"bob", "ALICE", "Carol", "Dave"},
+			result:  &dbd.AnalyzeResult{Phase: dbd.PhaseTrial, Usernames: lobby},
+			want:    matchScanInterval,
+		},
+		{
+			name:     "alert-relevant stream is always fast",
+			prev:     5 * time.Minute,
+			relevant: true,
+			result:   &dbd.AnalyzeResult{},
+			want:     fastScanInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &StreamTask{
+				Stream: database.Stream{
+					PlayerNames:  tt.players,
+					ScanInterval: int32(tt.prev.Seconds()),
+				},
+				Relevant: tt.relevant,
+			}
+
+			assert.Equal(t, tt.want, nextScanInterval(task, tt.result))
+		})
+	}
+}
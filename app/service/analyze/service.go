@@ -7,6 +7,9 @@ import (
 	"hyperfocus/app/client/twitch_live"
 	"hyperfocus/app/config"
 	"hyperfocus/app/database"
+	"hyperfocus/app/service/alias"
+	"hyperfocus/app/service/feed"
+	"hyperfocus/app/util"
 	"hyperfocus/app/util/dbd"
 	"hyperfocus/app/util/telemetry"
 	"image"
@@ -16,6 +19,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/elliotchance/pie/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/rofleksey/meg"
 	"github.com/samber/do"
 	"github.com/samber/oops"
@@ -23,13 +29,21 @@ import (
 
 var serviceName = "analyze"
 
+var errNothingToScan = errors.New("nothing to scan")
+
+// idleDelay is the pause between the loop iterations when no stream is due for a scan
+var idleDelay = time.Second
+
 type Service struct {
 	cfg           *config.Config
 	queries       database.TxQueries
+	transactor    database.TxTransactor
 	tracing       *telemetry.Tracing
 	liveClient    *twitch_live.Client
 	frameGrabber  *frame_grabber.Client
 	imageAnalyzer *dbd.ImageAnalyzer
+	aliasService  *alias.Service
+	feedService   *feed.Service
 }
 
 func New(di *do.Injector) (*Service, error) {
@@ -38,25 +52,38 @@ func New(di *do.Injector) (*Service, error) {
 	return &Service{
 		cfg:           do.MustInvoke[*config.Config](di),
 		queries:       do.MustInvoke[database.TxQueries](di),
+		transactor:    do.MustInvoke[database.TxTransactor](di),
 		tracing:       do.MustInvoke[*telemetry.Tracing](di),
 		liveClient:    do.MustInvoke[*twitch_live.Client](di),
 		frameGrabber:  do.MustInvoke[*frame_grabber.Client](di),
 		imageAnalyzer: do.MustInvoke[*dbd.ImageAnalyzer](di),
+		aliasService:  do.MustInvoke[*alias.Service](di),
+		feedService:   do.MustInvoke[*feed.Service](di),
 	}, nil
 }
 
 func (s *Service) doProcessing(ctx context.Context) error {
 	started := time.Now()
 
-	streams, err := s.queries.GetOnlineStreams(ctx)
+	streams, err := s.queries.GetDueStreams(ctx, started)
 	if err != nil {
-		return oops.Errorf("GetOnlineStreams: %w", err)
+		return oops.Errorf("GetDueStreams: %w", err)
 	}
 	if len(streams) == 0 {
-		return nil
+		return errNothingToScan
 	}
 
+	cycleID := uuid.New()
+
+	relevantStreams, err := s.getAlertRelevantStreams(ctx)
+	if err != nil {
+		return oops.Errorf("getAlertRelevantStreams: %w", err)
+	}
+
+	sessionsEnabled := s.cfg.Processing.Sessions.MaxCount > 0
+
 	slog.Debug("Starting processing",
+		slog.String("cycle_id", cycleID.String()),
 		slog.Int("fetch_worker_count", s.cfg.Processing.FetchWorkerCount),
 		slog.Int("process_worker_count", s.cfg.Processing.ProcessWorkerCount),
 		slog.Int("proxy_count", len(s.cfg.Proxy.List)),
@@ -83,9 +110,14 @@ func (s *Service) doProcessing(ctx context.Context) error {
 
 	wg.Go(func() {
 		for index, stream := range streams {
+			_, relevant := relevantStreams[stream.ID]
+
 			fetchChan <- &StreamTask{
-				Index:  index,
-				Stream: stream,
+				Index:    index,
+				Stream:   stream,
+				CycleID:  cycleID,
+				Relevant: relevant,
+				Session:  relevant && sessionsEnabled,
 			}
 		}
 		close(fetchChan)
@@ -130,7 +162,8 @@ func (s *Service) runFetchWorker(ctx context.Context, taskChan chan *StreamTask,
 
 		task.Mutex.Lock()
 		task.Frame = frameImg
-		task.Error = err != nil
+		task.FrameTime = time.Now()
+		task.Err = err
 		task.Mutex.Unlock()
 
 		resultChan <- task
@@ -139,12 +172,27 @@ func (s *Service) runFetchWorker(ctx context.Context, taskChan chan *StreamTask,
 
 func (s *Service) runProcessWorker(ctx context.Context, taskChan chan *StreamTask) {
 	for task := range taskChan {
-		if err := s.processChannel(ctx, task); err != nil {
+		result, err := s.processChannel(ctx, task)
+		if err != nil {
 			slog.ErrorContext(ctx, "Error processing channel",
 				slog.String("channel_name", task.Stream.ID),
 				slog.Any("error", err),
 			)
 		}
+
+		if err = s.recordAnalysis(ctx, task, result, err); err != nil {
+			slog.ErrorContext(ctx, "Error recording channel analysis",
+				slog.String("channel_name", task.Stream.ID),
+				slog.Any("error", err),
+			)
+		}
+
+		if err = s.updateSchedule(ctx, task, result); err != nil {
+			slog.ErrorContext(ctx, "Error updating channel schedule",
+				slog.String("channel_name", task.Stream.ID),
+				slog.Any("error", err),
+			)
+		}
 	}
 }
 
@@ -160,7 +208,7 @@ func (s *Service) fetchChannelFrame(ctx context.Context, task *StreamTask) (imag
 		proxy = s.cfg.Proxy.List[rand.Intn(len(s.cfg.Proxy.List))]
 	}
 
-	frameImg, err := s.obtainStreamFrame(ctx, task.Stream, proxy)
+	frameImg, err := s.obtainStreamFrame(ctx, task.Stream, proxy, task.Session)
 	if err != nil {
 		return nil, oops.Errorf("obtainStreamFrame: %w", err)
 	}
@@ -179,14 +227,16 @@ func (s *Service) fetchChannelFrame(ctx context.Context, task *StreamTask) (imag
 	return frameImg, nil
 }
 
-func (s *Service) processChannel(ctx context.Context, task *StreamTask) error {
+// processChannel analyzes the fetched frame, result is nil if there was nothing to analyze
+func (s *Service) processChannel(ctx context.Context, task *StreamTask) (*dbd.AnalyzeResult, error) {
 	task.Mutex.Lock()
 	frameImg := task.Frame
-	taskErr := task.Error
+	frameTime := task.FrameTime
+	taskErr := task.Err
 	task.Mutex.Unlock()
 
-	if frameImg == nil || taskErr {
-		return nil
+	if frameImg == nil || taskErr != nil {
+		return nil, nil
 	}
 
 	//started := time.Now()
@@ -195,18 +245,78 @@ func (s *Service) processChannel(ctx context.Context, task *StreamTask) error {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	data, err := s.imageAnalyzer.AnalyzeImage(ctx, frameImg)
+	data, err := s.imageAnalyzer.AnalyzeImage(ctx, task.Stream.ID, frameImg)
 	if err != nil {
-		return oops.Errorf("AnalyzeBytes: %w", err)
+		return nil, withCategory(categoryOCR, oops.Errorf("AnalyzeBytes: %w", err))
 	}
 
-	if err = s.queries.UpdateStreamData(ctx, database.UpdateStreamDataParams{
-		ID:          task.Stream.ID,
-		PlayerNames: meg.NonNilSlice(data.Usernames),
-	}); err != nil {
-		return oops.Errorf("UpdateStreamData: %w", err)
+	if len(data.Nicknames) == 0 {
+		slog.Debug("No players on the frame",
+			slog.String("channel_name", task.Stream.ID),
+			slog.String("phase", string(data.Phase)),
+			slog.Any("phase_scores", data.PhaseScores),
+		)
 	}
 
+	err = s.transactor.Transaction(ctx, func(ctx context.Context, _ pgx.Tx, qtx database.TxQueries) error {
+		if err := qtx.UpdateStreamData(ctx, database.UpdateStreamDataParams{
+			ID:          task.Stream.ID,
+			PlayerNames: meg.NonNilSlice(data.Usernames),
+			LastCycleID: &task.CycleID,
+			Phase:       string(data.Phase),
+			Players:     mapPlayers(data.Nicknames),
+		}); err != nil {
+			return oops.Errorf("UpdateStreamData: %w", err)
+		}
+
+		// the indexed copy of the lobby used by the search
+		if err := qtx.DeleteStreamNicknames(ctx, task.Stream.ID); err != nil {
+			return oops.Errorf("DeleteStreamNicknames: %w", err)
+		}
+
+		if err := qtx.CreateStreamNicknames(ctx, database.CreateStreamNicknamesParams{
+			StreamID:            task.Stream.ID,
+			Nicknames:           meg.NonNilSlice(data.Usernames),
+			NormalizedNicknames: pie.Map(meg.NonNilSlice(data.Usernames), util.NormalizeNickname),
+		}); err != nil {
+			return oops.Errorf("CreateStreamNicknames: %w", err)
+		}
+
+		for _, nickname := range data.Nicknames {
+			slot := int32(nickname.Slot)
+
+			if err := qtx.CreateSighting(ctx, database.CreateSightingParams{
+				StreamID:           task.Stream.ID,
+				Nickname:           nickname.Text,
+				NormalizedNickname: util.NormalizeNickname(nickname.Text),
+				Confidence:         nickname.Confidence,
+				ObservedAt:         frameTime,
+				CycleID:            task.CycleID,
+				Slot:               &slot,
+				Bbox:               boxValues(nickname.Box),
+			}); err != nil {
+				return oops.Errorf("CreateSighting: %w", err)
+			}
+		}
+
+		if err := s.aliasService.RecordSightings(ctx, qtx, task.Stream.ID, data.Phase, data.Nicknames, frameTime); err != nil {
+			return oops.Errorf("aliasService.RecordSightings: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, withCategory(categoryDatabase, oops.Errorf("transactor.Transaction: %w", err))
+	}
+
+	s.feedService.PublishAnalysis(feed.Analysis{
+		Stream:     task.Stream.ID,
+		AnalyzedAt: frameTime,
+		Phase:      string(data.Phase),
+		Nicknames:  meg.NonNilSlice(data.Usernames),
+		Players:    mapPlayers(data.Nicknames),
+	})
+
 	//slog.Debug("Finished processing channel",
 	//	slog.Int("index", task.Index),
 	//	slog.String("channel_name", task.Stream.ID),
@@ -218,7 +328,7 @@ func (s *Service) processChannel(ctx context.Context, task *StreamTask) error {
 	//	util.SaveDebugImage(frameImg, fmt.Sprintf("%s-%d", task.Stream.ID, len(data.Usernames)))
 	//}
 
-	return nil
+	return data, nil
 }
 
 func (s *Service) RunProcessLoop(ctx context.Context) {
@@ -230,7 +340,16 @@ func (s *Service) RunProcessLoop(ctx context.Context) {
 			default:
 			}
 
-			if err := s.doProcessing(ctx); err != nil {
+			err := s.doProcessing(ctx)
+			if errors.Is(err, errNothingToScan) {
+				select {
+				case <-ctx.Done():
+				case <-time.After(idleDelay):
+				}
+				continue
+			}
+
+			if err != nil {
 				slog.ErrorContext(ctx, "Processing failed",
 					slog.Any("error", err),
 				)
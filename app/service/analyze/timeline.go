@@ -0,0 +1,138 @@
+package analyze
+
+import (
+	"context"
+	"errors"
+	"hyperfocus/app/client/frame_grabber"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util/dbd"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/oops"
+)
+
+// skip reasons of the stream analyses, empty if the frame was analyzed
+const (
+	skipOffline = "offline"
+	skipAds     = "ads"
+	skipError   = "error"
+)
+
+// categories of the fetch and process errors
+const (
+	categoryTimeout  = "timeout"
+	categoryPlaylist = "playlist"
+	categoryQuality  = "quality"
+	categoryFrame    = "frame"
+	categoryOCR      = "ocr"
+	categoryDatabase = "database"
+	categoryUnknown  = "unknown"
+)
+
+// categorizedError marks the stage of the scan the error comes from
+type categorizedError struct {
+	category string
+	err      error
+}
+
+func (e *categorizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorizedError) Unwrap() error {
+	return e.err
+}
+
+func withCategory(category string, err error) error {
+	return &categorizedError{
+		category: category,
+		err:      err,
+	}
+}
+
+// errorCategory returns the category of the fetch or process error, timeouts win over the stage
+func errorCategory(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return categoryTimeout
+	case errors.Is(err, ErrNoOptimalStreamQuality):
+		return categoryQuality
+	case errors.Is(err, dbd.ErrNoOCREngines):
+		return categoryOCR
+	}
+
+	var categorized *categorizedError
+	if errors.As(err, &categorized) {
+		return categorized.category
+	}
+
+	return categoryUnknown
+}
+
+// newStreamAnalysis describes the outcome of the scan, result is nil if nothing was analyzed
+// and err is the fetch or process error
+func newStreamAnalysis(task *StreamTask, result *dbd.AnalyzeResult, err error) database.CreateStreamAnalysisParams {
+	analysis := database.CreateStreamAnalysisParams{
+		StreamID:   task.Stream.ID,
+		CycleID:    task.CycleID,
+		AnalyzedAt: task.FrameTime,
+		Phase:      string(dbd.PhaseUnknown),
+		Players:    database.StreamPlayers{},
+	}
+
+	switch {
+	case errors.Is(err, frame_grabber.ErrNoLiveSegment):
+		analysis.SkipReason = skipAds
+	case err != nil:
+		category := errorCategory(err)
+		analysis.SkipReason = skipError
+		analysis.ErrorCategory = &category
+	case result == nil:
+		analysis.SkipReason = skipOffline
+	default:
+		analysis.Phase = string(result.Phase)
+		analysis.Players = mapPlayers(result.Nicknames)
+	}
+
+	return analysis
+}
+
+// recordAnalysis appends the scan outcome to the stream timeline, keeping only the latest entries
+func (s *Service) recordAnalysis(ctx context.Context, task *StreamTask, result *dbd.AnalyzeResult, processErr error) error {
+	task.Mutex.Lock()
+	err := task.Err
+	task.Mutex.Unlock()
+
+	if err == nil {
+		err = processErr
+	}
+
+	analysis := newStreamAnalysis(task, result, err)
+
+	return s.transactor.Transaction(ctx, func(ctx context.Context, _ pgx.Tx, qtx database.TxQueries) error {
+		if err := qtx.CreateStreamAnalysis(ctx, analysis); err != nil {
+			return oops.Errorf("CreateStreamAnalysis: %w", err)
+		}
+
+		if err := qtx.DeleteOldStreamAnalyses(ctx, database.DeleteOldStreamAnalysesParams{
+			StreamID: task.Stream.ID,
+			Keep:     int32(s.cfg.Processing.TimelineSize),
+		}); err != nil {
+			return oops.Errorf("DeleteOldStreamAnalyses: %w", err)
+		}
+
+		if analysis.ErrorCategory == nil {
+			return nil
+		}
+
+		if err := qtx.SetStreamError(ctx, database.SetStreamErrorParams{
+			ID:                task.Stream.ID,
+			LastErrorCategory: analysis.ErrorCategory,
+			LastErrorAt:       &analysis.AnalyzedAt,
+		}); err != nil {
+			return oops.Errorf("SetStreamError: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,85 @@
+package analyze
+
+import (
+	"context"
+	"errors"
+	"hyperfocus/app/client/frame_grabber"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util/dbd"
+	"testing"
+
+	"github.com/samber/oops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{
+			err:  withCategory(categoryFrame, oops.Errorf("GrabFrameFromM3U8: %w", errors.New("ffmpeg failed"))),
+			want: categoryFrame,
+		},
+		{
+			err:  oops.Errorf("obtainStreamFrame: %w", withCategory(categoryPlaylist, errors.New("bad gateway"))),
+			want: categoryPlaylist,
+		},
+		{
+			err:  withCategory(categoryPlaylist, oops.Errorf("GetM3U8: %w", context.DeadlineExceeded)),
+			want: categoryTimeout,
+		},
+		{
+			err:  oops.Errorf("selectOptimalStreamQuality: %w", ErrNoOptimalStreamQuality),
+			want: categoryQuality,
+		},
+		{
+			err:  errors.New("something else"),
+			want: categoryUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, errorCategory(tt.err), tt.err.Error())
+	}
+}
+
+func TestNewStreamAnalysis(t *testing.T) {
+	task := &StreamTask{Stream: database.Stream{ID: "k0per1s"}}
+
+	analyzed := newStreamAnalysis(task, &dbd.AnalyzeResult{
+		Phase:     dbd.PhaseTrial,
+		Nicknames: []dbd.Nickname{{Text: "Demi", Confidence: 0.9, Slot: 1}},
+	}, nil)
+	assert.Empty(t, analyzed.SkipReason)
+	assert.Nil(t, analyzed.ErrorCategory)
+	assert.Equal(t, string(dbd.PhaseTrial), analyzed.Phase)
+	require.Len(t, analyzed.Players, 1)
+	assert.Equal(t, "Demi", analyzed.Players[0].Nickname)
+
+	scoreboard := newStreamAnalysis(task, &dbd.AnalyzeResult{
+		Phase: dbd.PhaseScoreboard,
+		Nicknames: []dbd.Nickname{
+			{Text: "Demi", Confidence: 0.9, Slot: 0, Role: dbd.RoleSurvivor},
+			{Text: "crstalnexus", Confidence: 0.8, Slot: 1, Role: dbd.RoleKiller},
+		},
+	}, nil)
+	require.Len(t, scoreboard.Players, 2)
+	assert.Equal(t, string(dbd.RoleKiller), scoreboard.Players[1].Role)
+
+	offline := newStreamAnalysis(task, nil, nil)
+	assert.Equal(t, skipOffline, offline.SkipReason)
+	assert.Nil(t, offline.ErrorCategory)
+
+	ads := newStreamAnalysis(task, nil, oops.Errorf("obtainStreamFrame: %w", frame_grabber.ErrNoLiveSegment))
+	assert.Equal(t, skipAds, ads.SkipReason)
+	assert.Nil(t, ads.ErrorCategory)
+
+	failed := newStreamAnalysis(task, nil, withCategory(categoryOCR, errors.New("paddle is down")))
+	assert.Equal(t, skipError, failed.SkipReason)
+	require.NotNil(t, failed.ErrorCategory)
+	assert.Equal(t, categoryOCR, *failed.ErrorCategory)
+	assert.Equal(t, string(dbd.PhaseUnknown), failed.Phase)
+	assert.NotNil(t, failed.Players)
+}
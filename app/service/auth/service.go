@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"errors"
+	"hyperfocus/app/config"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rofleksey/rbac"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+const (
+	RoleAdmin  = "admin"
+	RoleViewer = "viewer"
+)
+
+const (
+	PermissionReadAlerts  = "read:alerts"
+	PermissionWriteAlerts = "write:alerts"
+
+	PermissionReadAliases  = "read:aliases"
+	PermissionWriteAliases = "write:aliases"
+)
+
+var ErrAuthDisabled = errors.New("authentication is disabled")
+var ErrInvalidToken = errors.New("invalid token")
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+type Service struct {
+	cfg    *config.Config
+	policy rbac.Policy
+}
+
+func New(di *do.Injector) (*Service, error) {
+	builder := rbac.NewPolicyBuilder()
+
+	builder.MustRegisterRole(RoleAdmin)
+	builder.MustRegisterRole(RoleViewer)
+
+	builder.MustRegisterPermission(PermissionReadAlerts)
+	builder.MustRegisterPermission(PermissionWriteAlerts)
+	builder.MustRegisterPermission(PermissionReadAliases)
+	builder.MustRegisterPermission(PermissionWriteAliases)
+
+	builder.MustGrant(RoleAdmin, "*")
+	builder.MustGrant(RoleViewer, "read:*")
+
+	return &Service{
+		cfg:    do.MustInvoke[*config.Config](di),
+		policy: builder.Build(),
+	}, nil
+}
+
+func (s *Service) IssueToken(username string, roles []string) (string, error) {
+	if s.cfg.Auth.JWTSecret == "" {
+		return "", ErrAuthDisabled
+	}
+
+	for _, role := range roles {
+		if !s.policy.RoleExists(role) {
+			return "", oops.Errorf("unknown role: %s", role)
+		}
+	}
+
+	now := time.Now()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{ //nolint:exhaustruct
+			Subject:   username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(s.cfg.Auth.TokenTTL) * time.Hour)),
+		},
+		Roles: roles,
+	})
+
+	signed, err := token.SignedString([]byte(s.cfg.Auth.JWTSecret))
+	if err != nil {
+		return "", oops.Errorf("SignedString: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (s *Service) ParseToken(tokenStr string) (*Claims, error) {
+	if s.cfg.Auth.JWTSecret == "" {
+		return nil, ErrAuthDisabled
+	}
+
+	var claims Claims
+
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(_ *jwt.Token) (any, error) {
+		return []byte(s.cfg.Auth.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, oops.Errorf("ParseWithClaims: %w", err)
+	}
+	if !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+func (s *Service) IsGranted(claims *Claims, permissions ...string) bool {
+	for _, permission := range permissions {
+		if !s.policy.IsGranted(permission, claims.Roles...) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"hyperfocus/app/config"
+	"hyperfocus/app/database"
+	"hyperfocus/app/service/auth"
+	"hyperfocus/app/service/search"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/simonfxr/pubsub"
+)
+
+// bus topics, nickname subscriptions filter the analyses of all streams
+const (
+	analysesBusTopic     = "analyses"
+	alertsBusTopic       = "alerts"
+	streamBusTopicPrefix = "stream:"
+)
+
+// Analysis is a frame analysis stored by the analyze service
+type Analysis struct {
+	Stream     string
+	AnalyzedAt time.Time
+	Phase      string
+	Nicknames  []string
+	Players    database.StreamPlayers
+}
+
+// Alert is a fired streamsniping alert
+type Alert struct {
+	AlertStreamer   string
+	TargetStreamer  string
+	Message         string
+	MatchedNickname string
+	MatchScore      float64
+	MutualScore     float64
+	Timestamp       time.Time
+}
+
+// Event is delivered to the subscribers of the topic, exactly one of Analysis and Alert is set
+type Event struct {
+	Topic    string
+	Analysis *Analysis
+	// Match is the best nickname of the lobby matching the query of a nickname topic
+	Match *search.Match
+	Alert *Alert
+}
+
+// Service fans out analyses and alerts to the live feed subscribers,
+// publishing never blocks on slow subscribers
+type Service struct {
+	cfg           *config.Config
+	bus           *pubsub.Bus
+	authService   *auth.Service
+	searchService *search.Service
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		cfg:           do.MustInvoke[*config.Config](di),
+		bus:           pubsub.NewBus(),
+		authService:   do.MustInvoke[*auth.Service](di),
+		searchService: do.MustInvoke[*search.Service](di),
+	}, nil
+}
+
+// PublishAnalysis sends the stored analysis to the subscribers of the stream and of the matching nicknames
+func (s *Service) PublishAnalysis(analysis Analysis) {
+	s.bus.Publish(streamBusTopicPrefix+analysis.Stream, analysis)
+	s.bus.Publish(analysesBusTopic, analysis)
+}
+
+// PublishAlert sends the fired alert to the alerts subscribers
+func (s *Service) PublishAlert(alert Alert) {
+	s.bus.Publish(alertsBusTopic, alert)
+}
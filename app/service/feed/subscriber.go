@@ -0,0 +1,229 @@
+package feed
+
+import (
+	"hyperfocus/app/database"
+	"hyperfocus/app/service/auth"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/samber/oops"
+	"github.com/simonfxr/pubsub"
+)
+
+// topics the clients subscribe to
+const (
+	// TopicAlerts delivers all fired alerts, it needs the read:alerts permission
+	TopicAlerts = "alerts"
+	// TopicStreamPrefix followed by the streamer login delivers the analyses of the stream
+	TopicStreamPrefix = "stream:"
+	// TopicNicknamePrefix followed by the query delivers the analyses of the lobbies with a matching nickname
+	TopicNicknamePrefix = "nickname:"
+)
+
+var errUnknownTopic = oops.
+	With("status_code", http.StatusBadRequest).
+	Public("unknown topic").
+	New("unknown topic")
+
+var errTooManyTopics = oops.
+	With("status_code", http.StatusBadRequest).
+	Public("too many topics").
+	New("too many topics")
+
+var errAlertsForbidden = oops.
+	With("status_code", http.StatusForbidden).
+	Public("alerts topic needs the read:alerts permission").
+	New("alerts topic needs the read:alerts permission")
+
+// Subscriber is a live feed client. Its events are queued up to the configured buffer size,
+// events that don't fit are dropped and counted instead of blocking the publishers.
+type Subscriber struct {
+	service *Service
+	claims  *auth.Claims
+	events  chan Event
+	dropped atomic.Int64
+
+	mutex         sync.Mutex
+	subscriptions map[string]subscription
+}
+
+// subscription is a subscribed topic, stop is closed to end the matching goroutine of a nickname topic
+type subscription struct {
+	bus  *pubsub.Subscription
+	stop chan struct{}
+}
+
+// NewSubscriber creates a subscriber, claims are nil for anonymous clients
+func (s *Service) NewSubscriber(claims *auth.Claims) *Subscriber {
+	return &Subscriber{
+		service:       s,
+		claims:        claims,
+		events:        make(chan Event, s.cfg.Feed.BufferSize),
+		subscriptions: make(map[string]subscription),
+	}
+}
+
+// Events returns the queued events of the subscribed topics
+func (sub *Subscriber) Events() <-chan Event {
+	return sub.events
+}
+
+// TakeDropped returns the number of events dropped since the previous call
+func (sub *Subscriber) TakeDropped() int64 {
+	return sub.dropped.Swap(0)
+}
+
+// Subscribe starts delivering the events of the topic, subscribing twice to the same topic is a no-op
+func (sub *Subscriber) Subscribe(topic string) error {
+	topic, ok := parseTopic(topic)
+	if !ok {
+		return errUnknownTopic
+	}
+
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	if _, ok := sub.subscriptions[topic]; ok {
+		return nil
+	}
+	if len(sub.subscriptions) >= sub.service.cfg.Feed.MaxTopics {
+		return errTooManyTopics
+	}
+
+	var result subscription
+
+	switch {
+	case topic == TopicAlerts:
+		if sub.claims == nil || !sub.service.authService.IsGranted(sub.claims, auth.PermissionReadAlerts) {
+			return errAlertsForbidden
+		}
+
+		result.bus = sub.service.bus.Subscribe(alertsBusTopic, func(alert Alert) {
+			sub.enqueue(Event{Topic: topic, Alert: &alert})
+		})
+
+	case strings.HasPrefix(topic, TopicStreamPrefix):
+		result.bus = sub.service.bus.Subscribe(streamBusTopicPrefix+strings.TrimPrefix(topic, TopicStreamPrefix),
+			func(analysis Analysis) {
+				sub.enqueue(Event{Topic: topic, Analysis: &analysis})
+			})
+
+	default:
+		// the analyses of all streams are matched in a goroutine of the subscription, not by the publisher
+		analyses := make(chan Analysis, sub.service.cfg.Feed.BufferSize)
+		result.stop = make(chan struct{})
+
+		result.bus = sub.service.bus.Subscribe(analysesBusTopic, func(analysis Analysis) {
+			select {
+			case analyses <- analysis:
+			default:
+				sub.dropped.Add(1)
+			}
+		})
+
+		go sub.matchNickname(topic, strings.TrimPrefix(topic, TopicNicknamePrefix), analyses, result.stop)
+	}
+
+	sub.subscriptions[topic] = result
+
+	return nil
+}
+
+// Unsubscribe stops delivering the events of the topic, already queued events are still delivered
+func (sub *Subscriber) Unsubscribe(topic string) {
+	topic, _ = parseTopic(topic)
+
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	if subscription, ok := sub.subscriptions[topic]; ok {
+		sub.unsubscribe(subscription)
+		delete(sub.subscriptions, topic)
+	}
+}
+
+// Topics returns the subscribed topics in alphabetical order
+func (sub *Subscriber) Topics() []string {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	result := make([]string, 0, len(sub.subscriptions))
+	for topic := range sub.subscriptions {
+		result = append(result, topic)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// Close unsubscribes from all topics
+func (sub *Subscriber) Close() {
+	sub.mutex.Lock()
+	defer sub.mutex.Unlock()
+
+	for topic, subscription := range sub.subscriptions {
+		sub.unsubscribe(subscription)
+		delete(sub.subscriptions, topic)
+	}
+}
+
+func (sub *Subscriber) unsubscribe(subscription subscription) {
+	sub.service.bus.Unsubscribe(subscription.bus)
+
+	if subscription.stop != nil {
+		close(subscription.stop)
+	}
+}
+
+// matchNickname enqueues the analyses with a nickname matching the query until stop is closed
+func (sub *Subscriber) matchNickname(topic, query string, analyses <-chan Analysis, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case analysis := <-analyses:
+			match, ok := sub.service.searchService.MatchStream(database.Stream{
+				ID:          analysis.Stream,
+				PlayerNames: analysis.Nicknames,
+			}, query)
+			if !ok {
+				continue
+			}
+
+			sub.enqueue(Event{Topic: topic, Analysis: &analysis, Match: &match})
+		}
+	}
+}
+
+// enqueue is called by the publishers, so it must not block
+func (sub *Subscriber) enqueue(event Event) {
+	select {
+	case sub.events <- event:
+	default:
+		sub.dropped.Add(1)
+	}
+}
+
+// parseTopic normalizes the topic so that equal topics share a subscription, ok is false for unknown topics
+func parseTopic(topic string) (string, bool) {
+	topic = strings.TrimSpace(topic)
+
+	if topic == TopicAlerts {
+		return topic, true
+	}
+
+	if login, ok := strings.CutPrefix(topic, TopicStreamPrefix); ok {
+		login = strings.ToLower(strings.TrimSpace(login))
+		return TopicStreamPrefix + login, login != ""
+	}
+
+	if query, ok := strings.CutPrefix(topic, TopicNicknamePrefix); ok {
+		query = strings.TrimSpace(query)
+		return TopicNicknamePrefix + query, query != ""
+	}
+
+	return topic, false
+}
@@ -0,0 +1,166 @@
+package feed
+
+import (
+	"hyperfocus/app/config"
+	"hyperfocus/app/database"
+	"hyperfocus/app/service/alias"
+	"hyperfocus/app/service/auth"
+	"hyperfocus/app/service/search"
+	"hyperfocus/app/util/telemetry"
+	"testing"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/simonfxr/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newTestService(t *testing.T, bufferSize int) *Service {
+	t.Helper()
+
+	cfg := &config.Config{
+		Feed: config.Feed{
+			BufferSize: bufferSize,
+			MaxTopics:  2,
+		},
+		Search: config.Search{
+			MaxDistance: 3,
+		},
+	}
+
+	di := do.New()
+	do.ProvideValue(di, cfg)
+	do.ProvideValue(di, telemetry.NewTracing(cfg, noop.NewTracerProvider().Tracer("test")))
+	// matching the analyses doesn't touch the database
+	do.ProvideValue[database.TxQueries](di, nil)
+	do.ProvideValue[database.TxTransactor](di, nil)
+	do.Provide(di, alias.New)
+
+	authService, err := auth.New(di)
+	require.NoError(t, err)
+
+	searchService, err := search.New(di)
+	require.NoError(t, err)
+
+	return &Service{
+		cfg:           cfg,
+		bus:           pubsub.NewBus(),
+		authService:   authService,
+		searchService: searchService,
+	}
+}
+
+func TestParseTopic(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  string
+		ok    bool
+	}{
+		{"alerts", "alerts", true},
+		{" stream:K0per1s ", "stream:k0per1s", true},
+		{"nickname: Demi Joy ", "nickname:Demi Joy", true},
+		{"stream:", "stream:", false},
+		{"nickname:  ", "nickname:", false},
+		{"everything", "everything", false},
+	}
+
+	for _, tt := range tests {
+		topic, ok := parseTopic(tt.topic)
+		assert.Equal(t, tt.want, topic, tt.topic)
+		assert.Equal(t, tt.ok, ok, tt.topic)
+	}
+}
+
+func TestSubscriber_StreamTopic(t *testing.T) {
+	service := newTestService(t, 4)
+
+	sub := service.NewSubscriber(nil)
+	defer sub.Close()
+
+	require.NoError(t, sub.Subscribe("stream:K0per1s"))
+	require.NoError(t, sub.Subscribe("stream:k0per1s"))
+	assert.Equal(t, []string{"stream:k0per1s"}, sub.Topics())
+
+	service.PublishAnalysis(Analysis{Stream: "xweza"})
+	service.PublishAnalysis(Analysis{Stream: "k0per1s", Nicknames: []string{"Demi"}})
+
+	require.Len(t, sub.Events(), 1)
+	event := <-sub.Events()
+	assert.Equal(t, "stream:k0per1s", event.Topic)
+	assert.Equal(t, []string{"Demi"}, event.Analysis.Nicknames)
+
+	sub.Unsubscribe("stream:k0per1s")
+	service.PublishAnalysis(Analysis{Stream: "k0per1s"})
+	assert.Empty(t, sub.Events())
+}
+
+func TestSubscriber_DropsWhenBehind(t *testing.T) {
+	service := newTestService(t, 2)
+
+	sub := service.NewSubscriber(nil)
+	defer sub.Close()
+
+	require.NoError(t, sub.Subscribe("stream:k0per1s"))
+
+	for range 5 {
+		service.PublishAnalysis(Analysis{Stream: "k0per1s"})
+	}
+
+	assert.Len(t, sub.Events(), 2)
+	assert.Equal(t, int64(3), sub.TakeDropped())
+	assert.Zero(t, sub.TakeDropped())
+}
+
+func TestSubscriber_Limits(t *testing.T) {
+	service := newTestService(t, 2)
+
+	anonymous := service.NewSubscriber(nil)
+	defer anonymous.Close()
+
+	require.EqualError(t, anonymous.Subscribe("alerts"), errAlertsForbidden.Error())
+	require.EqualError(t, anonymous.Subscribe("everything"), errUnknownTopic.Error())
+
+	require.NoError(t, anonymous.Subscribe("stream:a"))
+	require.NoError(t, anonymous.Subscribe("stream:b"))
+	require.EqualError(t, anonymous.Subscribe("stream:c"), errTooManyTopics.Error())
+
+	viewer := service.NewSubscriber(&auth.Claims{Roles: []string{auth.RoleViewer}})
+	defer viewer.Close()
+
+	require.NoError(t, viewer.Subscribe("alerts"))
+
+	service.PublishAlert(Alert{AlertStreamer: "k0per1s", TargetStreamer: "xweza"})
+
+	require.Len(t, viewer.Events(), 1)
+	event := <-viewer.Events()
+	assert.Equal(t, "xweza", event.Alert.TargetStreamer)
+	assert.Empty(t, anonymous.Events())
+}
+
+func TestSubscriber_NicknameTopic(t *testing.T) {
+	service := newTestService(t, 4)
+
+	sub := service.NewSubscriber(nil)
+	defer sub.Close()
+
+	require.NoError(t, sub.Subscribe("nickname:Demi"))
+
+	service.PublishAnalysis(Analysis{Stream: "xweza", Nicknames: []string{"Vise47s", "Claudette Morel_01"}})
+	service.PublishAnalysis(Analysis{Stream: "demuxa", Nicknames: []string{"Demi", "LennoxNvm"}})
+
+	// the analyses are matched outside of the publisher, the first delivered one is the matching one
+	select {
+	case event := <-sub.Events():
+		assert.Equal(t, "nickname:Demi", event.Topic)
+		assert.Equal(t, "demuxa", event.Analysis.Stream)
+		require.NotNil(t, event.Match)
+		assert.Equal(t, "Demi", event.Match.Nickname)
+	case <-time.After(time.Second):
+		require.Fail(t, "the matching analysis was not delivered")
+	}
+
+	sub.Unsubscribe("nickname:Demi")
+	assert.Empty(t, sub.Topics())
+}
@@ -0,0 +1,253 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"hyperfocus/app/config"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// benchmarkStreams streams with benchmarkLobbySize nicknames each, one sighting per nickname
+const (
+	benchmarkStreams   = 25_000
+	benchmarkLobbySize = 4
+)
+
+// the search queries before the nicknames were indexed by trigrams, ordered like the indexed queries
+const (
+	sequentialSearchStreams = `
+SELECT streams.*
+FROM streams
+       CROSS JOIN LATERAL (SELECT min(CASE
+                                        WHEN normalize_nickname(nickname) LIKE '%' || $1 || '%' THEN 0
+                                        ELSE levenshtein(normalize_nickname(nickname), $1) END) AS distance
+                           FROM unnest(player_names) AS nickname) matches
+WHERE online = true
+  AND matches.distance < $2
+ORDER BY matches.distance
+LIMIT $3`
+
+	sequentialSearchSightings = `
+SELECT stream_id, min(observed_at), max(observed_at) AS last_seen, count(*), array_agg(DISTINCT nickname)
+FROM sightings
+WHERE observed_at BETWEEN $1 AND $2
+  AND (levenshtein(normalized_nickname, $3) < $4 OR normalized_nickname LIKE '%' || $3 || '%')
+GROUP BY stream_id
+ORDER BY min(CASE
+               WHEN normalized_nickname LIKE '%' || $3 || '%' THEN 0
+               ELSE levenshtein(normalized_nickname, $3) END),
+         last_seen DESC
+LIMIT $5`
+)
+
+var nicknameParts = []string{
+	"dark", "light", "shadow", "killer", "survivor", "ghost", "wolf", "night", "blood", "moon",
+	"fog", "hook", "gen", "trap", "nurse", "claudette", "dwight", "meg", "jake", "feng",
+	"tunnel", "camp", "loop", "pallet", "window", "hatch", "totem", "chest", "flash", "torch",
+}
+
+// BenchmarkSearchStreamsByNickname compares the trigram prefilter with the sequential scan and the exhaustive query
+// on a synthetic dataset with 100k nicknames. It needs a migrated database from config.yaml, the dataset is rolled back.
+func BenchmarkSearchStreamsByNickname(b *testing.B) {
+	ctx := context.Background()
+	tx, query := seedBenchmarkDataset(ctx, b)
+	queries := database.New(tx)
+
+	b.Run("sequential_scan", func(b *testing.B) {
+		for range b.N {
+			rows, err := tx.Query(ctx, sequentialSearchStreams, query, 6, maxCandidates)
+			require.NoError(b, err)
+			rows.Close()
+			require.NoError(b, rows.Err())
+		}
+	})
+
+	params := database.SearchStreamsByNicknameParams{
+		Query:      query,
+		Pattern:    util.EscapeLikeQuery(query),
+		Distance:   6,
+		MaxResults: maxCandidates,
+	}
+
+	recorder := &planRecorder{Tx: tx}
+	_, err := database.New(recorder).SearchStreamsByNickname(ctx, params)
+	require.NoError(b, err)
+	require.Contains(b, recorder.plan, "stream_nicknames_normalized_nickname_trgm_idx")
+	b.Log(recorder.plan)
+
+	b.Run("trigram_index", func(b *testing.B) {
+		for range b.N {
+			_, err := queries.SearchStreamsByNickname(ctx, params)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("exhaustive", func(b *testing.B) {
+		for range b.N {
+			_, err := queries.SearchStreamsByNicknameExhaustive(ctx, database.SearchStreamsByNicknameExhaustiveParams(params))
+			require.NoError(b, err)
+		}
+	})
+}
+
+// BenchmarkSearchSightingsByNickname compares the trigram prefilter with the sequential scan and the exhaustive query
+// on a synthetic dataset with 100k sightings. It needs a migrated database from config.yaml, the dataset is rolled back.
+func BenchmarkSearchSightingsByNickname(b *testing.B) {
+	ctx := context.Background()
+	tx, query := seedBenchmarkDataset(ctx, b)
+	queries := database.New(tx)
+
+	until := time.Now().Add(time.Minute)
+	since := until.Add(-defaultHistoryWindow)
+
+	b.Run("sequential_scan", func(b *testing.B) {
+		for range b.N {
+			rows, err := tx.Query(ctx, sequentialSearchSightings, since, until, query, 6, maxCandidates)
+			require.NoError(b, err)
+			rows.Close()
+			require.NoError(b, rows.Err())
+		}
+	})
+
+	params := database.SearchSightingsByNicknameParams{
+		Since:      since,
+		Until:      until,
+		Query:      query,
+		Pattern:    util.EscapeLikeQuery(query),
+		Distance:   6,
+		MaxResults: maxCandidates,
+	}
+
+	recorder := &planRecorder{Tx: tx}
+	_, err := database.New(recorder).SearchSightingsByNickname(ctx, params)
+	require.NoError(b, err)
+	require.Contains(b, recorder.plan, "sightings_normalized_nickname_trgm_idx")
+	b.Log(recorder.plan)
+
+	b.Run("trigram_index", func(b *testing.B) {
+		for range b.N {
+			_, err := queries.SearchSightingsByNickname(ctx, params)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("exhaustive", func(b *testing.B) {
+		for range b.N {
+			_, err := queries.SearchSightingsByNicknameExhaustive(ctx, database.SearchSightingsByNicknameExhaustiveParams{
+				Since:      params.Since,
+				Until:      params.Until,
+				Query:      params.Query,
+				Distance:   params.Distance,
+				Pattern:    params.Pattern,
+				MaxResults: params.MaxResults,
+			})
+			require.NoError(b, err)
+		}
+	})
+}
+
+// planRecorder records the generic plan of the last query before running it. The prepared statements of pgx
+// switch to the generic plan after a few executions, and the benchmarks force it from the start.
+// EXPLAIN (GENERIC_PLAN) needs PostgreSQL 16.
+type planRecorder struct {
+	pgx.Tx
+	plan string
+}
+
+func (r *planRecorder) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := r.Tx.Query(ctx, "EXPLAIN (GENERIC_PLAN) "+sql)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	r.plan = strings.Join(lines, "\n")
+
+	return r.Tx.Query(ctx, sql, args...)
+}
+
+// seedBenchmarkDataset fills the database with synthetic streams and sightings in a transaction
+// that is rolled back after the benchmark, returns the normalized query to search for
+func seedBenchmarkDataset(ctx context.Context, b *testing.B) (pgx.Tx, string) {
+	b.Helper()
+
+	cfg, err := config.Load("../../../config.yaml")
+	require.NoError(b, err)
+
+	connStr := "postgres://" + cfg.DB.User + ":" + cfg.DB.Pass + "@" + cfg.DB.Host + "/" + cfg.DB.Database + "?sslmode=disable"
+
+	conn, err := pgx.Connect(ctx, connStr)
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		_ = conn.Close(ctx)
+	})
+
+	tx, err := conn.Begin(ctx)
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		_ = tx.Rollback(ctx)
+	})
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	now := time.Now()
+	cycleID := uuid.New()
+
+	var streams, nicknames, sightings [][]any
+
+	for i := range benchmarkStreams {
+		streamID := fmt.Sprintf("benchmark_%d", i)
+
+		lobby := make([]string, benchmarkLobbySize)
+		for j := range lobby {
+			lobby[j] = nicknameParts[rnd.IntN(len(nicknameParts))] + "_" +
+				nicknameParts[rnd.IntN(len(nicknameParts))] + fmt.Sprint(rnd.IntN(1000))
+
+			normalized := util.NormalizeNickname(lobby[j])
+			nicknames = append(nicknames, []any{streamID, lobby[j], normalized})
+			sightings = append(sightings, []any{streamID, lobby[j], normalized, 0.9, now, cycleID})
+		}
+
+		streams = append(streams, []any{streamID, true, lobby})
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"streams"}, []string{"id", "online", "player_names"}, pgx.CopyFromRows(streams))
+	require.NoError(b, err)
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"stream_nicknames"},
+		[]string{"stream_id", "nickname", "normalized_nickname"}, pgx.CopyFromRows(nicknames))
+	require.NoError(b, err)
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"sightings"},
+		[]string{"stream_id", "nickname", "normalized_nickname", "confidence", "observed_at", "cycle_id"},
+		pgx.CopyFromRows(sightings))
+	require.NoError(b, err)
+
+	_, err = tx.Exec(ctx, "ANALYZE streams, stream_nicknames, sightings")
+	require.NoError(b, err)
+
+	_, err = tx.Exec(ctx, "SELECT set_config('pg_trgm.similarity_threshold', '0.3', true)")
+	require.NoError(b, err)
+
+	// the plan the prepared statements end up with, the parameter values are not known to the planner
+	_, err = tx.Exec(ctx, "SET LOCAL plan_cache_mode = force_generic_plan")
+	require.NoError(b, err)
+
+	// a nickname from the dataset with a typo
+	query := []rune(nicknames[len(nicknames)/2][2].(string))
+	query[len(query)/2] = 'x'
+
+	return tx, string(query)
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"hyperfocus/app/database"
+	"hyperfocus/app/util"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MatchKind tells how the nickname matched the query, from the strongest to the weakest
+type MatchKind string
+
+const (
+	MatchExact      MatchKind = "exact"
+	MatchNormalized MatchKind = "normalized"
+	MatchDistance   MatchKind = "distance"
+	MatchSubstring  MatchKind = "substring"
+)
+
+// scores of the match kinds, every kind is ranked above the weaker ones
+const (
+	exactScore        = 1.0
+	normalizedScore   = 0.95
+	maxDistanceScore  = 0.9
+	minDistanceScore  = 0.5
+	maxSubstringScore = 0.5
+)
+
+// Match is a stream whose lobby contains a nickname matching the query
+type Match struct {
+	Stream database.Stream
+	// Nickname is the best matching nickname of the lobby
+	Nickname string
+	Kind     MatchKind
+	// Score is in (0, 1], 1 means the nickname is exactly the query
+	Score float64
+}
+
+// scoreNickname rates how well the nickname matches the query, ok is false if it does not match at all.
+// Nicknames match by the distance if their weighted edit distance to the query is below maxDistance.
+func scoreNickname(nickname, query string, maxDistance float64) (MatchKind, float64, bool) {
+	if strings.EqualFold(strings.TrimSpace(nickname), strings.TrimSpace(query)) {
+		return MatchExact, exactScore, true
+	}
+
+	normalized := util.NormalizeNickname(nickname)
+	normalizedQuery := util.NormalizeNickname(query)
+	if normalized == "" || normalizedQuery == "" {
+		return "", 0, false
+	}
+
+	if normalized == normalizedQuery {
+		return MatchNormalized, normalizedScore, true
+	}
+
+	if distance := util.NicknameDistance(normalized, normalizedQuery); distance < maxDistance {
+		// the closer the nickname the higher it is, but still below the normalized match
+		return MatchDistance, minDistanceScore + (maxDistanceScore-minDistanceScore)*(1-distance/maxDistance), true
+	}
+
+	if strings.Contains(normalized, normalizedQuery) {
+		// the larger part of the nickname the query covers the higher it is
+		coverage := float64(utf8.RuneCountInString(normalizedQuery)) / float64(utf8.RuneCountInString(normalized))
+		return MatchSubstring, maxSubstringScore * coverage, true
+	}
+
+	return "", 0, false
+}
+
+// matchStream returns the best matching nickname of the stream lobby
+func matchStream(stream database.Stream, query string, maxDistance float64) (Match, bool) {
+	var best Match
+	found := false
+
+	for _, nickname := range stream.PlayerNames {
+		kind, score, ok := scoreNickname(nickname, query, maxDistance)
+		if !ok || (found && score <= best.Score) {
+			continue
+		}
+
+		best = Match{
+			Stream:   stream,
+			Nickname: nickname,
+			Kind:     kind,
+			Score:    score,
+		}
+		found = true
+	}
+
+	return best, found
+}
+
+// MatchStream returns the best nickname of the stream lobby matching the query, ok is false if none matches
+func (s *Service) MatchStream(stream database.Stream, query string) (Match, bool) {
+	return matchStream(stream, query, s.cfg.Search.MaxDistance)
+}
+
+// sortMatches orders the matches from the best to the worst, keeping the order of equal ones
+func sortMatches(matches []Match) {
+	slices.SortStableFunc(matches, func(a, b Match) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"hyperfocus/app/database"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreNickname(t *testing.T) {
+	tests := []struct {
+		nickname string
+		kind     MatchKind
+	}{
+		{"Demi", MatchExact},
+		{"DEMl", MatchNormalized},
+		{"Deml_", MatchDistance},
+		{"Demi_Joy", MatchSubstring},
+	}
+
+	prevScore := 2.0
+
+	for _, tt := range tests {
+		kind, score, ok := scoreNickname(tt.nickname, "demi", 3)
+		require.True(t, ok, tt.nickname)
+		assert.Equal(t, tt.kind, kind, tt.nickname)
+		assert.Less(t, score, prevScore, tt.nickname)
+
+		prevScore = score
+	}
+
+	_, _, ok := scoreNickname("Restaurante", "demi", 3)
+	assert.False(t, ok)
+}
+
+func TestMatchStream_BestNickname(t *testing.T) {
+	stream := database.Stream{
+		ID:          "guinas",
+		PlayerNames: []string{"Demi_Joy", "Nea Karlsson", "Demi"},
+	}
+
+	match, ok := matchStream(stream, "Demi", 3)
+	require.True(t, ok)
+	assert.Equal(t, "Demi", match.Nickname)
+	assert.Equal(t, MatchExact, match.Kind)
+}
+
+func TestBestMatches(t *testing.T) {
+	weak := Match{Stream: database.Stream{ID: "a"}, Kind: MatchSubstring, Score: 0.3}
+	strong := Match{Stream: database.Stream{ID: "a"}, Kind: MatchExact, Score: 1}
+	other := Match{Stream: database.Stream{ID: "b"}, Kind: MatchDistance, Score: 0.7}
+
+	result := BestMatches([]Match{weak, other, strong})
+
+	require.Len(t, result, 2)
+	assert.Equal(t, strong, result[0])
+	assert.Equal(t, other, result[1])
+}
@@ -2,47 +2,299 @@ package search
 
 import (
 	"context"
+	"hyperfocus/app/config"
 	"hyperfocus/app/database"
+	"hyperfocus/app/service/alias"
 	"hyperfocus/app/util"
 	"hyperfocus/app/util/telemetry"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/elliotchance/pie/v2"
+	"github.com/jackc/pgx/v5"
 	"github.com/samber/do"
 	"github.com/samber/oops"
 )
 
 var serviceName = "search"
 
-var maxDistance int32 = 3
-var maxResults int32 = 20
+var maxCandidates int32 = 200
+var defaultHistoryWindow = 24 * time.Hour
 
 type Service struct {
-	queries database.TxQueries
-	tracing *telemetry.Tracing
+	cfg          *config.Config
+	transactor   database.TxTransactor
+	tracing      *telemetry.Tracing
+	aliasService *alias.Service
 }
 
 func New(di *do.Injector) (*Service, error) {
 	return &Service{
-		queries: do.MustInvoke[database.TxQueries](di),
-		tracing: do.MustInvoke[*telemetry.Tracing](di),
+		cfg:          do.MustInvoke[*config.Config](di),
+		transactor:   do.MustInvoke[database.TxTransactor](di),
+		tracing:      do.MustInvoke[*telemetry.Tracing](di),
+		aliasService: do.MustInvoke[*alias.Service](di),
 	}, nil
 }
 
-func (s *Service) Search(ctx context.Context, query string) ([]database.Stream, error) {
+// candidateDistance bounds the plain edit distance of the candidates fetched from the database:
+// it is at most the weighted distance divided by the cheapest substitution,
+// the candidates are then filtered by the weighted distance
+func (s *Service) candidateDistance() int32 {
+	return int32(math.Ceil(s.cfg.Search.MaxDistance / util.ConfusableCost))
+}
+
+// typoSimilarity is the lowest trigram similarity between the normalized query and the query with a single
+// character replaced: pg_trgm pads every word with two spaces in front and one behind, so a word of n characters
+// has n+1 trigrams and the replaced character breaks up to 3 of them
+func typoSimilarity(normalized string) float64 {
+	words := strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	trigrams := 0
+	for _, word := range words {
+		trigrams += utf8.RuneCountInString(word) + 1
+	}
+
+	if trigrams <= 3 {
+		return 0
+	}
+
+	return float64(trigrams-3) / float64(trigrams+3)
+}
+
+// exhaustiveSearch reports whether the normalized query is too short for the trigram prefilter:
+// a single typo drops it below the similarity threshold ("katt" and "kaxt" are only 0.25 similar),
+// so the query is compared with every nickname instead
+func (s *Service) exhaustiveSearch(normalized string) bool {
+	return typoSimilarity(normalized) < s.cfg.Search.MinSimilarity || s.cfg.Search.MinSimilarity == 0
+}
+
+// withTrigramThreshold runs the callback in a transaction where the trigram index
+// returns the candidates with at least the configured similarity
+func (s *Service) withTrigramThreshold(ctx context.Context, callback func(ctx context.Context, qtx database.TxQueries) error) error {
+	return s.transactor.Transaction(ctx, func(ctx context.Context, _ pgx.Tx, qtx database.TxQueries) error {
+		threshold := strconv.FormatFloat(s.cfg.Search.MinSimilarity, 'f', -1, 64)
+		if err := qtx.SetSimilarityThreshold(ctx, threshold); err != nil {
+			return oops.Errorf("SetSimilarityThreshold: %w", err)
+		}
+
+		return callback(ctx, qtx)
+	})
+}
+
+// Search returns online streams with a nickname matching the query, the best matches first
+func (s *Service) Search(ctx context.Context, query string) ([]Match, error) {
 	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "search")
 	defer span.End()
 
-	query = util.EscapeLikeQuery(query)
+	data, err := s.searchStreams(ctx, query)
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("searchStreams: %w", err)) //nolint:exhaustruct
+	}
 
-	data, err := s.queries.SearchStreamsByNickname(ctx, database.SearchStreamsByNicknameParams{
-		Query:      query,
-		Distance:   maxDistance,
-		MaxResults: maxResults,
-	})
+	s.tracing.Success(span)
+
+	return truncate(data, s.cfg.Search.MaxResults), nil
+}
+
+// searchStreams returns all matching online streams sorted by score
+func (s *Service) searchStreams(ctx context.Context, query string) ([]Match, error) {
+	normalized := util.NormalizeNickname(query)
+
+	var candidates []database.Stream
+
+	if err := s.withTrigramThreshold(ctx, func(ctx context.Context, qtx database.TxQueries) error {
+		var err error
+
+		// the queries are separate, so that the prefiltered one always has a plan with the trigram index
+		if s.exhaustiveSearch(normalized) {
+			candidates, err = qtx.SearchStreamsByNicknameExhaustive(ctx, database.SearchStreamsByNicknameExhaustiveParams{
+				Query:      normalized,
+				Pattern:    util.EscapeLikeQuery(normalized),
+				Distance:   s.candidateDistance(),
+				MaxResults: maxCandidates,
+			})
+			if err != nil {
+				return oops.Errorf("SearchStreamsByNicknameExhaustive: %w", err)
+			}
+
+			return nil
+		}
+
+		candidates, err = qtx.SearchStreamsByNickname(ctx, database.SearchStreamsByNicknameParams{
+			Query:      normalized,
+			Pattern:    util.EscapeLikeQuery(normalized),
+			Distance:   s.candidateDistance(),
+			MaxResults: maxCandidates,
+		})
+		if err != nil {
+			return oops.Errorf("SearchStreamsByNickname: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, oops.Errorf("withTrigramThreshold: %w", err)
+	}
+
+	var result []Match
+
+	for _, stream := range candidates {
+		if match, ok := matchStream(stream, query, s.cfg.Search.MaxDistance); ok {
+			result = append(result, match)
+		}
+	}
+
+	sortMatches(result)
+
+	return result, nil
+}
+
+// SearchStreamer returns online streams whose lobby contains any of the known in-game names of the streamer,
+// every stream is matched by the best of the names
+func (s *Service) SearchStreamer(ctx context.Context, streamer string) ([]Match, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "search_streamer")
+	defer span.End()
+
+	streamer = strings.ToLower(streamer)
+
+	nicknames, err := s.aliasService.Nicknames(ctx, streamer)
 	if err != nil {
-		return nil, s.tracing.Error(span, oops.Errorf("SearchStreamsByNickname: %w", err)) //nolint:exhaustruct
+		return nil, s.tracing.Error(span, oops.Errorf("aliasService.Nicknames: %w", err)) //nolint:exhaustruct
 	}
 
+	var matches []Match
+
+	for _, nickname := range nicknames {
+		data, err := s.searchStreams(ctx, nickname)
+		if err != nil {
+			return nil, s.tracing.Error(span, oops.Errorf("searchStreams: %w", err)) //nolint:exhaustruct
+		}
+
+		// the streamer is obviously in their own lobby
+		matches = append(matches, pie.Filter(data, func(match Match) bool {
+			return match.Stream.ID != streamer
+		})...)
+	}
+
+	s.tracing.Success(span)
+
+	return truncate(BestMatches(matches), s.cfg.Search.MaxResults), nil
+}
+
+// BestMatches keeps the best match of every stream, sorted by score
+func BestMatches(matches []Match) []Match {
+	best := make(map[string]int, len(matches))
+
+	var result []Match
+
+	for _, match := range matches {
+		index, ok := best[match.Stream.ID]
+		if !ok {
+			best[match.Stream.ID] = len(result)
+			result = append(result, match)
+
+			continue
+		}
+
+		if match.Score > result[index].Score {
+			result[index] = match
+		}
+	}
+
+	sortMatches(result)
+
+	return result
+}
+
+func (s *Service) SearchHistory(ctx context.Context, query string, since, until *time.Time) ([]database.SearchSightingsByNicknameRow, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "search_history")
+	defer span.End()
+
+	now := time.Now()
+	if until == nil {
+		until = &now
+	}
+	if since == nil {
+		defaultSince := until.Add(-defaultHistoryWindow)
+		since = &defaultSince
+	}
+
+	normalized := util.NormalizeNickname(query)
+
+	var candidates []database.SearchSightingsByNicknameRow
+
+	if err := s.withTrigramThreshold(ctx, func(ctx context.Context, qtx database.TxQueries) error {
+		var err error
+
+		if s.exhaustiveSearch(normalized) {
+			rows, err := qtx.SearchSightingsByNicknameExhaustive(ctx, database.SearchSightingsByNicknameExhaustiveParams{
+				Since:      *since,
+				Until:      *until,
+				Query:      normalized,
+				Pattern:    util.EscapeLikeQuery(normalized),
+				Distance:   s.candidateDistance(),
+				MaxResults: maxCandidates,
+			})
+			if err != nil {
+				return oops.Errorf("SearchSightingsByNicknameExhaustive: %w", err)
+			}
+
+			candidates = pie.Map(rows, func(row database.SearchSightingsByNicknameExhaustiveRow) database.SearchSightingsByNicknameRow {
+				return database.SearchSightingsByNicknameRow(row)
+			})
+
+			return nil
+		}
+
+		candidates, err = qtx.SearchSightingsByNickname(ctx, database.SearchSightingsByNicknameParams{
+			Since:      *since,
+			Until:      *until,
+			Query:      normalized,
+			Pattern:    util.EscapeLikeQuery(normalized),
+			Distance:   s.candidateDistance(),
+			MaxResults: maxCandidates,
+		})
+		if err != nil {
+			return oops.Errorf("SearchSightingsByNickname: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("withTrigramThreshold: %w", err)) //nolint:exhaustruct
+	}
+
+	data := pie.Filter(candidates, func(row database.SearchSightingsByNicknameRow) bool {
+		return anyMatches(row.Nicknames, query, s.cfg.Search.MaxDistance)
+	})
+	// candidates come ordered by distance
+	slices.SortStableFunc(data, func(a, b database.SearchSightingsByNicknameRow) int {
+		return b.LastSeen.Compare(a.LastSeen)
+	})
+
 	s.tracing.Success(span)
 
-	return data, nil
+	return truncate(data, s.cfg.Search.MaxResults), nil
+}
+
+// anyMatches checks whether any of the nicknames matches the query
+func anyMatches(nicknames []string, query string, maxDistance float64) bool {
+	return pie.Any(nicknames, func(nickname string) bool {
+		_, _, ok := scoreNickname(nickname, query, maxDistance)
+		return ok
+	})
+}
+
+func truncate[T any](data []T, maxResults int) []T {
+	if len(data) > maxResults {
+		return data[:maxResults]
+	}
+
+	return data
 }
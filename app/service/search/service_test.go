@@ -0,0 +1,138 @@
+package search
+
+import (
+	"context"
+	"hyperfocus/app/config"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trigramSimilarity mimics the similarity function of pg_trgm
+func trigramSimilarity(a, b string) float64 {
+	trigrams := func(value string) map[string]struct{} {
+		result := make(map[string]struct{})
+
+		for _, word := range strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			padded := []rune("  " + word + " ")
+			for i := range len(padded) - 2 {
+				result[string(padded[i:i+3])] = struct{}{}
+			}
+		}
+
+		return result
+	}
+
+	setA, setB := trigrams(a), trigrams(b)
+
+	common := 0
+	for trigram := range setA {
+		if _, ok := setB[trigram]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(setA)+len(setB)-common)
+}
+
+// typos returns the query with every character replaced in turn
+func typos(query string) []string {
+	var result []string
+
+	for i := range []rune(query) {
+		typo := []rune(query)
+		typo[i] = 'x'
+		if strings.ContainsRune(query, 'x') {
+			typo[i] = 'j'
+		}
+
+		result = append(result, string(typo))
+	}
+
+	return result
+}
+
+func TestTypoSimilarity(t *testing.T) {
+	assert.InDelta(t, 0.25, typoSimilarity("katt"), 1e-9)
+	assert.InDelta(t, trigramSimilarity("katt", "kaxt"), typoSimilarity("katt"), 1e-9)
+
+	for _, query := range []string{"abc", "katt", "demi joy", "soma_01", "crstalnexus", "sunnlelemondrop"} {
+		for _, typo := range typos(query) {
+			assert.GreaterOrEqual(t, trigramSimilarity(query, typo), typoSimilarity(query), typo)
+		}
+	}
+}
+
+func TestExhaustiveSearch_ShortQueries(t *testing.T) {
+	service := &Service{cfg: &config.Config{Search: config.Search{MinSimilarity: 0.3}}}
+
+	assert.True(t, service.exhaustiveSearch(util.NormalizeNickname("Katt")))
+	assert.True(t, service.exhaustiveSearch(util.NormalizeNickname("abc")))
+	assert.False(t, service.exhaustiveSearch(util.NormalizeNickname("SunnieLemonDrop")))
+
+	// the trigram prefilter never drops a nickname with a single typo
+	for _, query := range []string{"abc", "katt", "demi", "nea", "soma_01", "k0per1s", "crstalnexus"} {
+		normalized := util.NormalizeNickname(query)
+		if service.exhaustiveSearch(normalized) {
+			continue
+		}
+
+		for _, typo := range typos(normalized) {
+			assert.GreaterOrEqual(t, trigramSimilarity(normalized, typo), service.cfg.Search.MinSimilarity, typo)
+		}
+	}
+
+	service.cfg.Search.MinSimilarity = 0
+	assert.True(t, service.exhaustiveSearch(util.NormalizeNickname("SunnieLemonDrop")))
+}
+
+// searchQuerier records which of the stream search queries ran
+type searchQuerier struct {
+	database.TxQueries
+	queries []string
+}
+
+func (q *searchQuerier) SetSimilarityThreshold(context.Context, string) error {
+	return nil
+}
+
+func (q *searchQuerier) SearchStreamsByNickname(context.Context, database.SearchStreamsByNicknameParams) ([]database.Stream, error) {
+	q.queries = append(q.queries, "SearchStreamsByNickname")
+	return nil, nil
+}
+
+func (q *searchQuerier) SearchStreamsByNicknameExhaustive(context.Context, database.SearchStreamsByNicknameExhaustiveParams) ([]database.Stream, error) {
+	q.queries = append(q.queries, "SearchStreamsByNicknameExhaustive")
+	return nil, nil
+}
+
+type querierTransactor struct {
+	qtx database.TxQueries
+}
+
+func (t querierTransactor) Transaction(ctx context.Context, callback func(ctx context.Context, tx pgx.Tx, qtx database.TxQueries) error) error {
+	return callback(ctx, nil, t.qtx)
+}
+
+func TestSearchStreams_ShortQueriesAreExhaustive(t *testing.T) {
+	queries := &searchQuerier{}
+	service := &Service{
+		cfg:        &config.Config{Search: config.Search{MaxDistance: 3, MinSimilarity: 0.3}},
+		transactor: querierTransactor{qtx: queries},
+	}
+
+	_, err := service.searchStreams(context.Background(), "Katt")
+	require.NoError(t, err)
+	_, err = service.searchStreams(context.Background(), "SunnieLemonDrop")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"SearchStreamsByNicknameExhaustive", "SearchStreamsByNickname"}, queries.queries)
+}
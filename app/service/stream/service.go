@@ -0,0 +1,215 @@
+package stream
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hyperfocus/app/database"
+	"hyperfocus/app/util/telemetry"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/do"
+	"github.com/samber/oops"
+)
+
+var serviceName = "stream"
+
+// sort orders of the listing, the same values are used by ListStreams
+const (
+	SortName         = "name"
+	SortViewers      = "viewers"
+	SortLastAnalyzed = "last_analyzed"
+)
+
+var errInvalidCursor = oops.
+	With("status_code", http.StatusBadRequest).
+	Public("invalid cursor").
+	New("invalid cursor")
+
+var errStreamNotFound = oops.
+	With("status_code", http.StatusNotFound).
+	Public("stream not found").
+	New("stream not found")
+
+// noAnalysisTime is the sort key of streams that were never analyzed, 'epoch' in ListStreams
+var noAnalysisTime = time.Unix(0, 0).UTC()
+
+type Service struct {
+	queries database.TxQueries
+	tracing *telemetry.Tracing
+}
+
+func New(di *do.Injector) (*Service, error) {
+	return &Service{
+		queries: do.MustInvoke[database.TxQueries](di),
+		tracing: do.MustInvoke[*telemetry.Tracing](di),
+	}, nil
+}
+
+// ListParams filters the listing, nil filters are not applied
+type ListParams struct {
+	// Cursor is the NextCursor of the previous page, empty for the first page
+	Cursor         string
+	Limit          int
+	Sort           string
+	Online         *bool
+	HasPlayers     *bool
+	AnalyzedAfter  *time.Time
+	AnalyzedBefore *time.Time
+	Language       *string
+	MinViewers     *int
+	MaxViewers     *int
+}
+
+type Page struct {
+	Streams []database.Stream
+	// NextCursor is empty on the last page
+	NextCursor string
+}
+
+// cursor points at the last stream of the page, it is only valid for the same sort order
+type cursor struct {
+	Sort     string    `json:"sort"`
+	ID       string    `json:"id"`
+	Viewers  int32     `json:"viewers,omitempty"`
+	Analyzed time.Time `json:"analyzed,omitzero"`
+}
+
+// List returns a page of the tracked streams
+func (s *Service) List(ctx context.Context, params ListParams) (*Page, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "list")
+	defer span.End()
+
+	queryParams := database.ListStreamsParams{
+		Online:         params.Online,
+		HasPlayers:     params.HasPlayers,
+		AnalyzedAfter:  params.AnalyzedAfter,
+		AnalyzedBefore: params.AnalyzedBefore,
+		Language:       params.Language,
+		MinViewers:     toInt32(params.MinViewers),
+		MaxViewers:     toInt32(params.MaxViewers),
+		Sort:           params.Sort,
+		// one more stream tells whether there is a next page
+		MaxResults: int32(params.Limit + 1),
+	}
+
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor)
+		if err != nil || after.Sort != params.Sort {
+			return nil, s.tracing.Error(span, errInvalidCursor)
+		}
+
+		queryParams.CursorID = &after.ID
+		queryParams.CursorViewers = after.Viewers
+		queryParams.CursorAnalyzed = after.Analyzed
+	}
+
+	streams, err := s.queries.ListStreams(ctx, queryParams)
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("ListStreams: %w", err))
+	}
+
+	page := &Page{
+		Streams: streams,
+	}
+
+	if len(streams) > params.Limit {
+		page.Streams = streams[:params.Limit]
+		page.NextCursor = encodeCursor(newCursor(params.Sort, page.Streams[len(page.Streams)-1]))
+	}
+
+	s.tracing.Success(span)
+
+	return page, nil
+}
+
+// Details is the current state of the stream along with its latest analyses
+type Details struct {
+	Stream database.Stream
+	// Timeline holds the latest analyses from the newest to the oldest
+	Timeline []database.StreamAnalysis
+}
+
+// Get returns the stream and its last timelineSize analyses
+func (s *Service) Get(ctx context.Context, id string, timelineSize int) (*Details, error) {
+	ctx, span := s.tracing.StartServiceSpan(ctx, serviceName, "get")
+	defer span.End()
+
+	id = strings.ToLower(id)
+
+	stream, err := s.queries.GetStream(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.tracing.Error(span, errStreamNotFound)
+		}
+
+		return nil, s.tracing.Error(span, oops.Errorf("GetStream: %w", err))
+	}
+
+	timeline, err := s.queries.GetStreamAnalyses(ctx, database.GetStreamAnalysesParams{
+		StreamID:   id,
+		MaxResults: int32(timelineSize),
+	})
+	if err != nil {
+		return nil, s.tracing.Error(span, oops.Errorf("GetStreamAnalyses: %w", err))
+	}
+
+	s.tracing.Success(span)
+
+	return &Details{
+		Stream:   stream,
+		Timeline: timeline,
+	}, nil
+}
+
+func newCursor(sort string, last database.Stream) cursor {
+	result := cursor{
+		Sort: sort,
+		ID:   last.ID,
+	}
+
+	switch sort {
+	case SortViewers:
+		result.Viewers = last.ViewerCount
+	case SortLastAnalyzed:
+		result.Analyzed = noAnalysisTime
+		if last.LastScanAt != nil {
+			result.Analyzed = *last.LastScanAt
+		}
+	}
+
+	return result
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var result cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return result, oops.Errorf("failed to decode cursor: %w", err)
+	}
+
+	if err = json.Unmarshal(data, &result); err != nil {
+		return result, oops.Errorf("failed to parse cursor: %w", err)
+	}
+
+	return result, nil
+}
+
+func toInt32(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+
+	result := int32(*v)
+	return &result
+}
@@ -0,0 +1,55 @@
+package stream
+
+import (
+	"hyperfocus/app/database"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	analyzed := time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		sort   string
+		stream database.Stream
+		want   cursor
+	}{
+		{
+			sort:   SortName,
+			stream: database.Stream{ID: "k0per1s", ViewerCount: 100},
+			want:   cursor{Sort: SortName, ID: "k0per1s"},
+		},
+		{
+			sort:   SortViewers,
+			stream: database.Stream{ID: "k0per1s", ViewerCount: 100},
+			want:   cursor{Sort: SortViewers, ID: "k0per1s", Viewers: 100},
+		},
+		{
+			sort:   SortLastAnalyzed,
+			stream: database.Stream{ID: "k0per1s", LastScanAt: &analyzed},
+			want:   cursor{Sort: SortLastAnalyzed, ID: "k0per1s", Analyzed: analyzed},
+		},
+		{
+			sort:   SortLastAnalyzed,
+			stream: database.Stream{ID: "xweza"},
+			want:   cursor{Sort: SortLastAnalyzed, ID: "xweza", Analyzed: noAnalysisTime},
+		},
+	}
+
+	for _, tt := range tests {
+		decoded, err := decodeCursor(encodeCursor(newCursor(tt.sort, tt.stream)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, decoded)
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	_, err := decodeCursor("not a cursor")
+	require.Error(t, err)
+
+	_, err = decodeCursor("bm90IGpzb24")
+	require.Error(t, err)
+}
@@ -65,8 +65,10 @@ func (s *Service) doFetch(ctx context.Context) error {
 			}
 
 			if err = s.queries.SetStreamOnline(ctx, database.SetStreamOnlineParams{
-				ID:      streamID,
-				Updated: started,
+				ID:          streamID,
+				Updated:     started,
+				Language:    stream.Language,
+				ViewerCount: int32(stream.ViewerCount),
 			}); err != nil {
 				return oops.Errorf("UpdateStreamOnline: %w", err)
 			}
@@ -3,81 +3,250 @@ package dbd
 import (
 	"context"
 	"fmt"
-	"hyperfocus/app/client/magick"
-	"hyperfocus/app/client/paddle"
+	"hyperfocus/app/client/ocr"
 	"hyperfocus/app/util"
+	"hyperfocus/app/util/imgproc"
+	"hyperfocus/app/util/telemetry"
 	"image"
+	"math"
 	"testing"
 
+	"github.com/elliotchance/pie/v2"
+	"github.com/jellydator/ttlcache/v3"
 	"github.com/samber/do"
 )
 
 type ImageAnalyzer struct {
-	paddleClient *paddle.Client
-	magickClient *magick.Client
+	ocrEngine OCREngine
+	metrics   *telemetry.Metrics
+	// hudCache holds the last recognized HUD of every stream
+	hudCache *ttlcache.Cache[string, hudCacheEntry]
 }
 
 func NewImageAnalyzer(di *do.Injector) (*ImageAnalyzer, error) {
+	ocrEngine, err := newConfiguredEngine(di)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCR engine: %w", err)
+	}
+
 	return &ImageAnalyzer{
-		paddleClient: do.MustInvoke[*paddle.Client](di),
-		magickClient: do.MustInvoke[*magick.Client](di),
+		ocrEngine: ocrEngine,
+		metrics:   do.MustInvoke[*telemetry.Metrics](di),
+		hudCache:  newHUDCache(),
 	}, nil
 }
 
+// HealthCheck fails only if none of the OCR engines is available
+func (a *ImageAnalyzer) HealthCheck(ctx context.Context) error {
+	return a.ocrEngine.HealthCheck(ctx)
+}
+
+type Nickname struct {
+	Text       string
+	Confidence float64
+	// Slot is the index of the HUD row the nickname is in
+	Slot int
+	// Role is only known on the end-game scoreboard, it is empty otherwise
+	Role Role
+	// Box is the bounding box of the nickname on the frame, empty if the OCR engine does not report it
+	Box image.Rectangle
+}
+
+// minNicknameConfidence is the OCR confidence below which the text is considered noise
+const minNicknameConfidence = 0.5
+
 type AnalyzeResult struct {
+	Phase     Phase
 	Usernames []string
+	// Nicknames holds the same usernames along with their OCR confidence, position and role
+	Nicknames []Nickname
+	// Killer is the nickname of the killer, it is only known on the end-game scoreboard
+	Killer string
+	// PhaseScores holds the row periodicity score of every phase layout, it explains the detected phase
+	PhaseScores map[Phase]float64
 }
 
-func (a *ImageAnalyzer) AnalyzeImage(ctx context.Context, img image.Image) (*AnalyzeResult, error) {
-	usernames, err := a.analyzeUsernames(ctx, img)
+// AnalyzeImage recognizes the players on the frame, key identifies the stream the frame belongs to:
+// if the HUD of the stream has not changed since the previous frame, its nicknames are reused without OCR.
+// Caching is disabled if key is empty.
+func (a *ImageAnalyzer) AnalyzeImage(ctx context.Context, key string, img image.Image) (*AnalyzeResult, error) {
+	detected := classifyPhase(img)
+	phase := detected.Phase
+
+	a.metrics.RecordFramePhase(ctx, string(phase), phaseScoreAttrs(detected.Scores))
+
+	// the names area is only known for the phases with player lists,
+	// and the uncalibrated layouts could have matched an overlay instead of the game UI
+	if !detected.Layout.Calibrated {
+		// nothing to recognize, skip the OCR round-trip
+		return &AnalyzeResult{
+			Phase:       phase,
+			PhaseScores: detected.Scores,
+		}, nil
+	}
+
+	if phase == PhaseScoreboard {
+		players, err := a.analyzeScoreboard(ctx, img, detected.Layout, detected.Spacing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze scoreboard: %w", err)
+		}
+
+		result := newScoreboardResult(players)
+		result.PhaseScores = detected.Scores
+
+		return result, nil
+	}
+
+	nicknames, err := a.analyzeUsernames(ctx, key, img, detected)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze usernames: %w", err)
 	}
 
 	return &AnalyzeResult{
-		Usernames: usernames,
+		Phase: phase,
+		Usernames: pie.Map(nicknames, func(n Nickname) string {
+			return n.Text
+		}),
+		Nicknames:   nicknames,
+		PhaseScores: detected.Scores,
 	}, nil
 }
 
-func (a *ImageAnalyzer) analyzeUsernames(ctx context.Context, img image.Image) ([]string, error) {
-	hudImage, err := a.magickClient.CropAndProcessForUsernames(ctx, img)
-	if err != nil {
-		return nil, fmt.Errorf("ProcessImageForOCR: %w", err)
+func phaseScoreAttrs(scores map[Phase]float64) map[string]float64 {
+	result := make(map[string]float64, len(scores))
+	for phase, score := range scores {
+		result[string(phase)] = score
+	}
+
+	return result
+}
+
+func newScoreboardResult(players []Player) *AnalyzeResult {
+	result := &AnalyzeResult{
+		Phase: PhaseScoreboard,
+	}
+
+	for _, player := range players {
+		result.Usernames = append(result.Usernames, player.Nickname)
+		result.Nicknames = append(result.Nicknames, Nickname{
+			Text:       player.Nickname,
+			Confidence: player.Confidence,
+			Slot:       player.Slot,
+			Role:       player.Role,
+			Box:        player.Box,
+		})
+
+		if player.Role == RoleKiller {
+			result.Killer = player.Nickname
+		}
 	}
 
+	return result
+}
+
+func (a *ImageAnalyzer) analyzeUsernames(ctx context.Context, key string, img image.Image, detected detection) ([]Nickname, error) {
+	layout := detected.Layout
+
+	// names are brought back to their size at 1080p and the default UI scale, which is what OCR is tuned for
+	resize := 1 / layout.Scale
+
+	// the HUD is hashed before the thresholds, they turn compression noise into flipped pixels
+	hudGray := imgproc.CropGray(img, layout.Names)
+	imgproc.AutoLevel(hudGray)
+
+	hash := imgproc.NewDHash(hudGray)
+	if nicknames, ok := a.cachedNicknames(ctx, key, layout.Names, hash); ok {
+		return nicknames, nil
+	}
+
+	hudImage := imgproc.ForUsernames(img, layout.Names, resize)
+
 	if testing.Testing() {
 		util.SaveDebugImageLocal(hudImage, "hudImage")
 	}
 
-	res, err := a.paddleClient.Recognize(ctx, hudImage)
+	res, err := a.ocrEngine.Recognize(ctx, hudImage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to recognize image: %w", err)
 	}
 
-	return a.parseUsernames(res), nil
+	fragments := parseFragments(res)
+	rows := locateNicknames(img, detected, resize, fragments)
+
+	nicknames := pie.Filter(extractSlots(fragments, rows), func(n Nickname) bool {
+		return n.Text != ""
+	})
+
+	a.cacheNicknames(key, layout.Names, hash, nicknames)
+
+	return nicknames, nil
 }
 
-func (a *ImageAnalyzer) parseUsernames(ocrResult *paddle.OCRResponse) []string {
-	var usernames []string
+// parseFragments keeps the confident OCR results, a single name may be split into several of them
+func parseFragments(ocrResult *ocr.Response) []Nickname {
+	var fragments []Nickname
 
 	for _, res := range ocrResult.Results {
-		if res.Confidence < 0.5 {
+		if res.Confidence < minNicknameConfidence {
 			continue
 		}
 
-		username := purifyUsername(res.Text)
-		if a.isValidUsername(username) {
-			usernames = append(usernames, username)
+		text := purifyUsername(res.Text)
+		if text == "" {
+			continue
 		}
+
+		fragments = append(fragments, Nickname{
+			Text:       text,
+			Confidence: res.Confidence,
+			Box:        res.Box,
+		})
 	}
 
-	return keepLongestFour(usernames)
+	return fragments
+}
+
+// hudRows are the evenly spaced rows of the HUD on the frame, rows are unknown if Spacing is zero
+type hudRows struct {
+	// First is the y coordinate of the text line of the first row
+	First   int
+	Spacing int
+}
+
+// line returns the y coordinate of the text line of the slot
+func (r hudRows) line(slot int) int {
+	return r.First + slot*r.Spacing
 }
 
-func (a *ImageAnalyzer) isValidUsername(username string) bool {
-	if len(username) < 3 {
-		return false
+// locateNicknames converts the boxes from the crop to the frame coordinates and assigns the HUD slots:
+// rows are evenly spaced, so the slot is the distance from the first row in row spacings.
+// Nicknames without boxes get slots in the reading order.
+func locateNicknames(img image.Image, detected detection, resize float64, nicknames []Nickname) hudRows {
+	area := detected.Layout.Names
+
+	var rows hudRows
+	if detected.Spacing > 0 {
+		rows = hudRows{
+			First:   area.Min.Y + rowOffset(img, area, detected.Spacing),
+			Spacing: detected.Spacing,
+		}
+	}
+
+	for i := range nicknames {
+		nickname := &nicknames[i]
+		nickname.Slot = i
+
+		if nickname.Box.Empty() {
+			continue
+		}
+
+		nickname.Box = cropToFrame(nickname.Box, area, resize)
+
+		if rows.Spacing > 0 {
+			center := (nickname.Box.Min.Y + nickname.Box.Max.Y) / 2
+			nickname.Slot = max(int(math.Round(float64(center-rows.First)/float64(rows.Spacing))), 0)
+		}
 	}
 
-	return true
+	return rows
 }
@@ -3,20 +3,26 @@ package dbd
 import (
 	"context"
 	"fmt"
-	"hyperfocus/app/client/magick"
+	"hyperfocus/app/client/ocr"
 	"hyperfocus/app/client/paddle"
+	"hyperfocus/app/client/tesseract"
 	"hyperfocus/app/config"
 	"hyperfocus/app/util"
+	"hyperfocus/app/util/telemetry"
 	"image"
+	"image/color"
+	"image/draw"
 	"image/jpeg"
 	"image/png"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/elliotchance/pie/v2"
 	"github.com/samber/do"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 func TestImageAnalyzer_AnalyzeImage(t *testing.T) {
@@ -66,8 +72,13 @@ func TestImageAnalyzer_AnalyzeImage(t *testing.T) {
 			require.NoError(t, err)
 
 			do.ProvideValue(di, cfg)
+
+			metrics, err := telemetry.NewMetrics(cfg, noop.NewMeterProvider().Meter("test"))
+			require.NoError(t, err)
+			do.ProvideValue(di, metrics)
+
 			do.Provide(di, paddle.NewClient)
-			do.Provide(di, magick.NewClient)
+			do.Provide(di, tesseract.NewClient)
 			do.Provide(di, NewImageAnalyzer)
 
 			file, err := os.Open(tt.imagePath)
@@ -84,7 +95,7 @@ func TestImageAnalyzer_AnalyzeImage(t *testing.T) {
 
 			analyzer := do.MustInvoke[*ImageAnalyzer](di)
 
-			data, err := analyzer.AnalyzeImage(context.Background(), img)
+			data, err := analyzer.AnalyzeImage(context.Background(), "", img)
 			require.NoError(t, err)
 			require.NotNil(t, data)
 
@@ -98,3 +109,125 @@ func TestImageAnalyzer_AnalyzeImage(t *testing.T) {
 		})
 	}
 }
+
+func TestLocateNicknames(t *testing.T) {
+	img := loadTestImage(t, "test_dataset/bigwill82_1.png")
+	detected := classifyPhase(img)
+	require.Equal(t, PhaseTrial, detected.Phase)
+
+	area := detected.Layout.Names
+	firstRow := rowOffset(img, area, detected.Spacing)
+
+	// the second row is empty, the names are in the first and the third rows
+	nicknames := []Nickname{
+		{Text: "Bigwill82", Box: image.Rect(10, firstRow-10, 100, firstRow+10)},
+		{Text: "Clappnz", Box: image.Rect(10, firstRow+2*detected.Spacing-12, 90, firstRow+2*detected.Spacing+8)},
+		{Text: "unknown"},
+	}
+
+	resize := 1 / detected.Layout.Scale
+	locateNicknames(img, detected, resize, nicknames)
+
+	assert.Equal(t, 0, nicknames[0].Slot)
+	assert.Equal(t, cropToFrame(image.Rect(10, firstRow-10, 100, firstRow+10), area, resize), nicknames[0].Box)
+	assert.Equal(t, 2, nicknames[1].Slot)
+	assert.Equal(t, 2, nicknames[2].Slot)
+	assert.True(t, nicknames[2].Box.Empty())
+}
+
+func TestExtractSlots(t *testing.T) {
+	img := loadTestImage(t, "test_dataset/demuxa_1.png")
+	detected := classifyPhase(img)
+	require.Equal(t, PhaseTrial, detected.Phase)
+
+	// word-level OCR output for the processed names area of the frame: the boxes are measured on the crop,
+	// the names are split at the spaces and at the camel case, the way OCR engines split them
+	fragments := []Nickname{
+		{Text: "Soma_01", Confidence: 0.6, Box: image.Rect(98, 127, 161, 144)},
+		{Text: "Demi", Confidence: 0.9, Box: image.Rect(42, 42, 78, 55)},
+		{Text: "Gabriel", Confidence: 0.9, Box: image.Rect(40, 128, 94, 145)},
+		// the bot tag to the right of the name
+		{Text: "BOT", Confidence: 0.9, Box: image.Rect(178, 131, 203, 140)},
+		{Text: "Lennox", Confidence: 0.9, Box: image.Rect(43, 215, 93, 230)},
+		{Text: "Nvm", Confidence: 0.8, Box: image.Rect(95, 218, 125, 232)},
+		{Text: "crstalnexus", Confidence: 0.9, Box: image.Rect(43, 304, 124, 321)},
+	}
+
+	resize := 1 / detected.Layout.Scale
+	rows := locateNicknames(img, detected, resize, fragments)
+
+	slots := extractSlots(fragments, rows)
+	require.Len(t, slots, survivorSlots)
+
+	// the labels of the frame in TestImageAnalyzer_AnalyzeImage
+	assert.Equal(t, []string{"Demi", "Gabriel Soma_01", "LennoxNvm", "crstalnexus"}, pie.Map(slots, func(n Nickname) string {
+		return n.Text
+	}))
+
+	for i, slot := range slots {
+		assert.Equal(t, i, slot.Slot)
+	}
+
+	assert.Equal(t, cropToFrame(image.Rect(40, 127, 161, 145), detected.Layout.Names, resize), slots[1].Box)
+	assert.InDelta(t, (0.9*7+0.6*7)/14, slots[1].Confidence, 1e-9)
+}
+
+func TestExtractSlots_Noise(t *testing.T) {
+	rows := hudRows{First: 500, Spacing: 88}
+
+	fragments := []Nickname{
+		// a short name in the first row with longer noise below it
+		{Text: "Demi", Confidence: 0.9, Slot: 0, Box: image.Rect(10, 490, 46, 503)},
+		{Text: "Obsession status", Confidence: 0.8, Slot: 0, Box: image.Rect(10, 520, 200, 536)},
+		// noise below the last row
+		{Text: "crstalnexus", Confidence: 0.9, Slot: 4, Box: image.Rect(10, 842, 91, 859)},
+	}
+
+	slots := extractSlots(fragments, rows)
+	require.Len(t, slots, survivorSlots)
+
+	assert.Equal(t, "Demi", slots[0].Text)
+	for _, slot := range slots[1:] {
+		assert.Empty(t, slot.Text)
+	}
+	assert.Equal(t, 2, slots[2].Slot)
+}
+
+// drawChatOverlay draws evenly spaced lines of "text" where streamers usually put the chat,
+// the same place the lobby player list is at
+func drawChatOverlay(img *image.RGBA) {
+	for y := 180; y < 740; y += 90 {
+		for x := 1400; x < 1800; x += 8 {
+			draw.Draw(img, image.Rect(x, y, x+4, y+14), image.NewUniform(color.White), image.Point{}, draw.Src)
+		}
+	}
+}
+
+func TestImageAnalyzer_UncalibratedLayout(t *testing.T) {
+	metrics, err := telemetry.NewMetrics(&config.Config{}, noop.NewMeterProvider().Meter("test")) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	engine := ocr.NewFixture(&ocr.Response{
+		Results: []ocr.Result{{Text: "xqc: KEKW", Confidence: 0.95}},
+	})
+
+	analyzer := &ImageAnalyzer{
+		ocrEngine: engine,
+		metrics:   metrics,
+		hudCache:  newHUDCache(),
+	}
+	t.Cleanup(analyzer.hudCache.Stop)
+
+	img := image.NewRGBA(image.Rect(0, 0, 1920, 1080))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	drawChatOverlay(img)
+
+	res, err := analyzer.AnalyzeImage(context.Background(), "k0per1s", img)
+	require.NoError(t, err)
+
+	// the chat passes for the lobby, but its lines are not taken for nicknames
+	assert.Equal(t, PhaseLobby, res.Phase)
+	assert.Empty(t, res.Usernames)
+	assert.Empty(t, res.Nicknames)
+	assert.Zero(t, engine.Calls())
+}
@@ -0,0 +1,64 @@
+package dbd
+
+import (
+	"context"
+	"hyperfocus/app/util/imgproc"
+	"image"
+	"slices"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+// maxHashDistance is the max Hamming distance between hashes of the HUD crops that are considered unchanged,
+// recompression of the same HUD stays below it while different name lists are 50+ bits apart
+const maxHashDistance = 6
+
+// hudCacheMaxAge bounds how long the result is reused, so that a missed change of a single name does not stick
+const hudCacheMaxAge = 2 * time.Minute
+
+type hudCacheEntry struct {
+	Hash      imgproc.DHash
+	Area      image.Rectangle
+	Nicknames []Nickname
+}
+
+func newHUDCache() *ttlcache.Cache[string, hudCacheEntry] {
+	cache := ttlcache.New[string, hudCacheEntry](
+		ttlcache.WithTTL[string, hudCacheEntry](hudCacheMaxAge),
+		ttlcache.WithDisableTouchOnHit[string, hudCacheEntry](),
+	)
+	go cache.Start()
+
+	return cache
+}
+
+// cachedNicknames returns the nicknames recognized on the previous crop of the stream if the crop has not changed
+func (a *ImageAnalyzer) cachedNicknames(ctx context.Context, key string, area image.Rectangle, hash imgproc.DHash) ([]Nickname, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	item := a.hudCache.Get(key)
+	hit := item != nil && item.Value().Area == area && item.Value().Hash.Distance(hash) <= maxHashDistance
+
+	a.metrics.RecordOCRCacheLookup(ctx, hit)
+
+	if !hit {
+		return nil, false
+	}
+
+	return slices.Clone(item.Value().Nicknames), true
+}
+
+func (a *ImageAnalyzer) cacheNicknames(key string, area image.Rectangle, hash imgproc.DHash, nicknames []Nickname) {
+	if key == "" {
+		return
+	}
+
+	a.hudCache.Set(key, hudCacheEntry{
+		Hash:      hash,
+		Area:      area,
+		Nicknames: slices.Clone(nicknames),
+	}, ttlcache.DefaultTTL)
+}
@@ -0,0 +1,54 @@
+package dbd
+
+import (
+	"context"
+	"hyperfocus/app/client/ocr"
+	"hyperfocus/app/config"
+	"hyperfocus/app/util/telemetry"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestImageAnalyzer_HUDCache(t *testing.T) {
+	metrics, err := telemetry.NewMetrics(&config.Config{}, noop.NewMeterProvider().Meter("test")) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	engine := ocr.NewFixture(&ocr.Response{
+		Results: []ocr.Result{{Text: "Bigwill82", Confidence: 0.95}},
+	})
+
+	analyzer := &ImageAnalyzer{
+		ocrEngine: engine,
+		metrics:   metrics,
+		hudCache:  newHUDCache(),
+	}
+	t.Cleanup(analyzer.hudCache.Stop)
+
+	first := loadTestImage(t, "test_dataset/bigwill82_1.png")
+	second := loadTestImage(t, "test_dataset/xweza_1.png")
+	ctx := context.Background()
+
+	res, err := analyzer.AnalyzeImage(ctx, "bigwill82", first)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bigwill82"}, res.Usernames)
+	assert.Equal(t, 1, engine.Calls())
+
+	// the same HUD is not recognized again
+	res, err = analyzer.AnalyzeImage(ctx, "bigwill82", first)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bigwill82"}, res.Usernames)
+	assert.Equal(t, 1, engine.Calls())
+
+	// another HUD of the same stream is
+	_, err = analyzer.AnalyzeImage(ctx, "bigwill82", second)
+	require.NoError(t, err)
+	assert.Equal(t, 2, engine.Calls())
+
+	// caching is disabled without the key
+	_, err = analyzer.AnalyzeImage(ctx, "", second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, engine.Calls())
+}
@@ -2,6 +2,7 @@ package dbd
 
 import (
 	"image"
+	"math"
 	"regexp"
 	"sort"
 	"strings"
@@ -14,42 +15,168 @@ type SubImager interface {
 	SubImage(r image.Rectangle) image.Image
 }
 
-func keepLongestFour(strings []string) []string {
-	if len(strings) <= 4 {
-		return strings
+// survivorSlots is the number of survivor rows in the HUD
+const survivorSlots = 4
+
+// extractSlots assembles one nickname per HUD slot from the OCR fragments, slots without a valid name are empty.
+// Fragments of a slot that overlap vertically with the one closest to the text line of the row are joined
+// left to right, so a name split by OCR is merged back while noise above or below the name is dropped.
+// Fragments must already have their slots assigned, see locateNicknames.
+func extractSlots(fragments []Nickname, rows hudRows) []Nickname {
+	slots := make([]Nickname, survivorSlots)
+
+	for slot := range slots {
+		slots[slot].Slot = slot
+
+		var row []Nickname
+		for _, fragment := range fragments {
+			if fragment.Slot == slot {
+				row = append(row, fragment)
+			}
+		}
+		if len(row) == 0 {
+			continue
+		}
+
+		nickname := mergeFragments(row, rows.line(slot), rows.Spacing > 0)
+		if isValidUsername(nickname.Text) {
+			slots[slot] = nickname
+		}
+	}
+
+	return slots
+}
+
+// mergeFragments joins the fragments of a single row that belong to the same line as the anchor:
+// the fragment closest to the text line of the row if it is known, the widest one otherwise
+func mergeFragments(row []Nickname, line int, lineKnown bool) Nickname {
+	distance := func(n Nickname) int {
+		return abs((n.Box.Min.Y+n.Box.Max.Y)/2 - line)
 	}
 
-	type stringWithIndex struct {
-		str   string
-		index int
+	anchor := row[0]
+	for _, fragment := range row[1:] {
+		if lineKnown && distance(fragment) != distance(anchor) {
+			if distance(fragment) < distance(anchor) {
+				anchor = fragment
+			}
+			continue
+		}
+
+		if fragment.Box.Dx() > anchor.Box.Dx() {
+			anchor = fragment
+		}
 	}
 
-	indexedStrings := make([]stringWithIndex, len(strings))
-	for i, s := range strings {
-		indexedStrings[i] = stringWithIndex{str: s, index: i}
+	// fragments without boxes can't be placed relative to each other
+	if anchor.Box.Empty() {
+		return anchor
 	}
 
-	sort.Slice(indexedStrings, func(i, j int) bool {
-		if len(indexedStrings[i].str) != len(indexedStrings[j].str) {
-			return len(indexedStrings[i].str) > len(indexedStrings[j].str)
+	var words []Nickname
+	for _, fragment := range row {
+		if fragment.Box.Min.Y < anchor.Box.Max.Y && anchor.Box.Min.Y < fragment.Box.Max.Y {
+			words = append(words, fragment)
 		}
-		return indexedStrings[i].index < indexedStrings[j].index
+	}
+
+	sort.SliceStable(words, func(i, j int) bool {
+		return words[i].Box.Min.X < words[j].Box.Min.X
 	})
 
-	longestFour := indexedStrings[:4]
+	words = nameWords(words, anchor)
 
-	sort.Slice(longestFour, func(i, j int) bool {
-		return longestFour[i].index < longestFour[j].index
-	})
+	result := words[0]
+
+	var builder strings.Builder
+	var weightedConfidence float64
+	var totalLength int
 
-	result := make([]string, 4)
-	for i, item := range longestFour {
-		result[i] = item.str
+	for i, fragment := range words {
+		if i > 0 && fragment.Box.Min.X-words[i-1].Box.Max.X >= spaceWidth(words[i-1], fragment) {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(fragment.Text)
+
+		// confidence of the merged name is weighted by the length of its fragments
+		weightedConfidence += fragment.Confidence * float64(len(fragment.Text))
+		totalLength += len(fragment.Text)
+
+		result.Box = result.Box.Union(fragment.Box)
 	}
 
+	result.Text = builder.String()
+	result.Confidence = weightedConfidence / float64(totalLength)
+
 	return result
 }
 
+// Gaps between the words of a line relative to the average character width of the words around the gap.
+// On the test_dataset HUDs letter gaps are up to 0.35 of the character width and spaces are 0.45 and more,
+// while tags like BOT next to the name are about two characters away.
+const (
+	minSpaceGap = 0.4
+	maxWordGap  = 1.5
+)
+
+// charWidth returns the average character width of the fragments
+func charWidth(fragments ...Nickname) float64 {
+	var width, length int
+	for _, fragment := range fragments {
+		width += fragment.Box.Dx()
+		length += len([]rune(fragment.Text))
+	}
+
+	if length == 0 {
+		return 0
+	}
+
+	return float64(width) / float64(length)
+}
+
+// spaceWidth returns the smallest gap between the neighbour fragments that is a space, narrower gaps mean
+// that OCR split a single word
+func spaceWidth(left, right Nickname) int {
+	return int(math.Ceil(charWidth(left, right) * minSpaceGap))
+}
+
+// nameWords keeps the words sorted left to right that are close enough to the anchor to be a part of the name
+func nameWords(words []Nickname, anchor Nickname) []Nickname {
+	index := 0
+	for i, word := range words {
+		if word.Box == anchor.Box && word.Text == anchor.Text {
+			index = i
+			break
+		}
+	}
+
+	tooFar := func(left, right Nickname) bool {
+		return float64(right.Box.Min.X-left.Box.Max.X) > charWidth(left, right)*maxWordGap
+	}
+
+	first, last := index, index
+	for first > 0 && !tooFar(words[first-1], words[first]) {
+		first--
+	}
+	for last < len(words)-1 && !tooFar(words[last], words[last+1]) {
+		last++
+	}
+
+	return words[first : last+1]
+}
+
+func isValidUsername(username string) bool {
+	return len(username) >= 3
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
 func purifyUsername(s string) string {
 	s = multipleSpacesRegex.ReplaceAllString(s, " ")
 	s = htmlTagsRegex.ReplaceAllString(s, " ")
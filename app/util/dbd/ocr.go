@@ -0,0 +1,156 @@
+package dbd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hyperfocus/app/client/ocr"
+	"hyperfocus/app/client/paddle"
+	"hyperfocus/app/client/tesseract"
+	"hyperfocus/app/config"
+	"image"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/samber/do"
+)
+
+// OCREngine recognizes text lines on the preprocessed crops
+type OCREngine interface {
+	Recognize(ctx context.Context, img image.Image) (*ocr.Response, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// engineCooldown is the time a failed engine is skipped for, so that an outage
+// does not cost a timeout on every frame
+const engineCooldown = time.Minute
+
+var ErrNoOCREngines = errors.New("no OCR engines available")
+
+// fallbackEngine tries the engines in order until one of them succeeds
+type fallbackEngine struct {
+	engines []ocr.Engine
+
+	mu          sync.Mutex
+	failedUntil map[string]time.Time
+}
+
+func newFallbackEngine(engines ...ocr.Engine) *fallbackEngine {
+	return &fallbackEngine{
+		engines:     engines,
+		failedUntil: make(map[string]time.Time),
+	}
+}
+
+// newConfiguredEngine builds the engine chain from the config
+func newConfiguredEngine(di *do.Injector) (*fallbackEngine, error) {
+	cfg := do.MustInvoke[*config.Config](di)
+
+	engines := make([]ocr.Engine, 0, len(cfg.OCR.Engines))
+
+	for _, name := range cfg.OCR.Engines {
+		switch name {
+		case "paddle":
+			engines = append(engines, do.MustInvoke[*paddle.Client](di))
+		case "tesseract":
+			engines = append(engines, do.MustInvoke[*tesseract.Client](di))
+		default:
+			return nil, fmt.Errorf("unknown OCR engine: %s", name)
+		}
+	}
+
+	return newFallbackEngine(engines...), nil
+}
+
+func (e *fallbackEngine) Recognize(ctx context.Context, img image.Image) (*ocr.Response, error) {
+	var errs []error
+
+	// if every engine is cooling down, all of them are tried anyway
+	for _, skipFailed := range []bool{true, false} {
+		for _, engine := range e.engines {
+			if skipFailed && e.isCoolingDown(engine) {
+				continue
+			}
+
+			res, err := engine.Recognize(ctx, img)
+			if err == nil {
+				e.markSucceeded(engine)
+				return res, nil
+			}
+
+			if ctx.Err() != nil {
+				return nil, err
+			}
+
+			e.markFailed(engine, err)
+			errs = append(errs, fmt.Errorf("%s: %w", engine.Name(), err))
+		}
+
+		if len(errs) > 0 {
+			break
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil, ErrNoOCREngines
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// HealthCheck checks all engines, it fails only if none of them is healthy
+func (e *fallbackEngine) HealthCheck(ctx context.Context) error {
+	var errs []error
+
+	for _, engine := range e.engines {
+		if err := engine.HealthCheck(ctx); err != nil {
+			slog.Warn("OCR engine is unhealthy",
+				slog.String("engine", engine.Name()),
+				slog.Any("error", err),
+			)
+
+			e.markFailed(engine, err)
+			errs = append(errs, fmt.Errorf("%s: %w", engine.Name(), err))
+		}
+	}
+
+	if len(errs) == len(e.engines) {
+		return errors.Join(append(errs, ErrNoOCREngines)...)
+	}
+
+	return nil
+}
+
+func (e *fallbackEngine) isCoolingDown(engine ocr.Engine) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return time.Now().Before(e.failedUntil[engine.Name()])
+}
+
+func (e *fallbackEngine) markFailed(engine ocr.Engine, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.failedUntil[engine.Name()]; !ok {
+		slog.Warn("OCR engine failed, falling back",
+			slog.String("engine", engine.Name()),
+			slog.Any("error", err),
+		)
+	}
+
+	e.failedUntil[engine.Name()] = time.Now().Add(engineCooldown)
+}
+
+func (e *fallbackEngine) markSucceeded(engine ocr.Engine) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.failedUntil[engine.Name()]; ok {
+		slog.Info("OCR engine recovered",
+			slog.String("engine", engine.Name()),
+		)
+		delete(e.failedUntil, engine.Name())
+	}
+}
@@ -0,0 +1,80 @@
+package dbd
+
+import (
+	"context"
+	"errors"
+	"hyperfocus/app/client/ocr"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errEngineDown = errors.New("engine is down")
+
+type namedFixture struct {
+	*ocr.Fixture
+	name string
+}
+
+func (f namedFixture) Name() string {
+	return f.name
+}
+
+func TestFallbackEngine(t *testing.T) {
+	primary := namedFixture{Fixture: ocr.NewFailingFixture(errEngineDown), name: "primary"}
+	secondary := namedFixture{Fixture: ocr.NewFixture(&ocr.Response{
+		Results: []ocr.Result{{Text: "Clappnz", Confidence: 0.9}},
+	}), name: "secondary"}
+
+	engine := newFallbackEngine(primary, secondary)
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+
+	res, err := engine.Recognize(context.Background(), img)
+	require.NoError(t, err)
+	require.Len(t, res.Results, 1)
+	assert.Equal(t, "Clappnz", res.Results[0].Text)
+	assert.Equal(t, 1, primary.Calls())
+
+	// the failed engine is skipped while cooling down
+	_, err = engine.Recognize(context.Background(), img)
+	require.NoError(t, err)
+	assert.Equal(t, 1, primary.Calls())
+	assert.Equal(t, 2, secondary.Calls())
+
+	require.NoError(t, engine.HealthCheck(context.Background()))
+}
+
+func TestFallbackEngine_AllFailed(t *testing.T) {
+	primary := namedFixture{Fixture: ocr.NewFailingFixture(errEngineDown), name: "primary"}
+	engine := newFallbackEngine(primary)
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+
+	_, err := engine.Recognize(context.Background(), img)
+	require.ErrorIs(t, err, errEngineDown)
+
+	// cooling down engines are still tried when nothing else is left
+	_, err = engine.Recognize(context.Background(), img)
+	require.ErrorIs(t, err, errEngineDown)
+	assert.Equal(t, 2, primary.Calls())
+
+	require.ErrorIs(t, engine.HealthCheck(context.Background()), ErrNoOCREngines)
+	require.ErrorIs(t, newFallbackEngine().HealthCheck(context.Background()), ErrNoOCREngines)
+}
+
+func TestParseFragments(t *testing.T) {
+	fragments := parseFragments(&ocr.Response{
+		Results: []ocr.Result{
+			{Text: "Bigwill82", Confidence: 0.98},
+			{Text: "ab", Confidence: 0.99},
+			{Text: "Clappnz", Confidence: 0.3},
+			{Text: "  ", Confidence: 0.9},
+		},
+	})
+
+	// short fragments are kept, they may be a part of a longer name
+	require.Len(t, fragments, 2)
+	assert.Equal(t, "Bigwill82", fragments[0].Text)
+	assert.Equal(t, "ab", fragments[1].Text)
+}
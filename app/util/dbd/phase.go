@@ -0,0 +1,380 @@
+package dbd
+
+import (
+	"image"
+	"math"
+)
+
+// Phase is the game phase shown on the frame
+type Phase string
+
+const (
+	PhaseLobby      Phase = "lobby"
+	PhaseTrial      Phase = "trial"
+	PhaseScoreboard Phase = "scoreboard"
+	PhaseMenu       Phase = "menu"
+	PhaseUnknown    Phase = "unknown"
+)
+
+// InGame returns whether the frame shows player names
+func (p Phase) InGame() bool {
+	return p == PhaseLobby || p == PhaseTrial || p == PhaseScoreboard
+}
+
+// rowLayout describes a vertical list of evenly spaced text rows, coordinates are for 1920x1080 frames
+// at the default UI scale
+type rowLayout struct {
+	// Anchor is the frame corner the layout is attached to, it stays in place when the frame
+	// aspect ratio or the UI scale changes
+	Anchor anchor
+	// Text is the area that contains the row texts
+	Text image.Rectangle
+	// Icons is the area to the left of the texts that contains per-row icons, optional
+	Icons image.Rectangle
+	// Names is the area with player names that is sent to OCR, optional
+	Names image.Rectangle
+	// MinSpacing and MaxSpacing bound the distance between rows, it depends on the UI scale
+	MinSpacing int
+	MaxSpacing int
+	// RowSpacing is the distance between rows at the default UI scale, optional.
+	// If set, the UI scale is measured by the detected spacing instead of the scale the rows were found at.
+	RowSpacing int
+	// Threshold is the minimal periodicity score of the rows
+	Threshold float64
+	// Scale is the factor the layout was resized by relative to 1920x1080 at the default UI scale
+	Scale float64
+	// Calibrated layouts are tuned on real frames, the others only label the frame and their text is not recognized
+	Calibrated bool
+}
+
+type anchor struct {
+	Right  bool
+	Bottom bool
+}
+
+// The trial layout is calibrated on the test_dataset frames, which are all in-trial: HUD row spacing is 70-90px
+// depending on the UI scale, the scores of the HUD are above 0.7 while the rest of the frame stays below 0.4.
+// The HUD is attached to the bottom left corner, its rows stay in place relative to it at every UI scale.
+// The scoreboard and lobby layouts are estimated from the game UI and are not calibrated yet: test_dataset has
+// no lobby, scoreboard or menu frames. Evenly spaced overlay text like a chat in their areas matches them as well,
+// so they only label the frames until they are calibrated: their names are not recognized and not stored.
+// The scores of every layout are exported as metrics to tune them on live streams.
+var (
+	trialLayout = rowLayout{
+		Anchor:     anchor{Right: false, Bottom: true},
+		Text:       image.Rect(140, 380, 400, 880),
+		Icons:      image.Rect(60, 380, 160, 880),
+		Names:      image.Rect(145, 420, 378, 835),
+		MinSpacing: 50,
+		MaxSpacing: 110,
+		RowSpacing: 88,
+		Threshold:  0.45,
+		Scale:      1,
+		Calibrated: true,
+	}
+	scoreboardLayout = rowLayout{
+		Anchor:     anchor{Right: false, Bottom: false},
+		Text:       image.Rect(300, 200, 900, 900),
+		MinSpacing: 90,
+		MaxSpacing: 150,
+		Threshold:  0.45,
+		Scale:      1,
+	}
+	lobbyLayout = rowLayout{
+		Anchor:     anchor{Right: true, Bottom: false},
+		Text:       image.Rect(1350, 150, 1850, 750),
+		Names:      image.Rect(1350, 150, 1850, 750),
+		MinSpacing: 70,
+		MaxSpacing: 140,
+		Threshold:  0.45,
+		Scale:      1,
+	}
+)
+
+// uiScales are the combined UI and HUD scale settings the layouts are searched at, from the default one.
+// The game allows 70-100% for each of them, so the smallest HUD is about a half of the default one.
+var uiScales = []float64{1, 0.9, 0.8, 0.7, 0.6, 0.5}
+
+// minUIScale and maxUIScale bound the measured UI scale
+const (
+	minUIScale = 0.4
+	maxUIScale = 1.2
+)
+
+const referenceWidth = 1920
+const referenceHeight = 1080
+
+// fit returns the layout for the frame bounds at the given UI scale.
+// The game scales its UI by the frame height, so the offsets from the anchor are scaled the same way.
+func (l rowLayout) fit(bounds image.Rectangle, uiScale float64) rowLayout {
+	scale := uiScale * float64(bounds.Dy()) / referenceHeight
+
+	result := l
+	result.Text = l.fitRect(bounds, l.Text, scale)
+	result.Icons = l.fitRect(bounds, l.Icons, scale)
+	result.Names = l.fitRect(bounds, l.Names, scale)
+	result.MinSpacing = max(int(math.Floor(float64(l.MinSpacing)*scale)), 2)
+	result.MaxSpacing = max(int(math.Ceil(float64(l.MaxSpacing)*scale)), result.MinSpacing+1)
+	result.Scale = scale
+
+	return result
+}
+
+// measureScale returns the UI scale that matches the detected row spacing
+func (l rowLayout) measureScale(bounds image.Rectangle, spacing int) float64 {
+	scale := float64(spacing) / float64(l.RowSpacing) * referenceHeight / float64(bounds.Dy())
+
+	return min(max(scale, minUIScale), maxUIScale)
+}
+
+func (l rowLayout) fitRect(bounds, rect image.Rectangle, scale float64) image.Rectangle {
+	if rect.Empty() {
+		return image.Rectangle{}
+	}
+
+	fitX := func(x int) int {
+		if l.Anchor.Right {
+			return bounds.Max.X - int(math.Round(float64(referenceWidth-x)*scale))
+		}
+
+		return bounds.Min.X + int(math.Round(float64(x)*scale))
+	}
+
+	fitY := func(y int) int {
+		if l.Anchor.Bottom {
+			return bounds.Max.Y - int(math.Round(float64(referenceHeight-y)*scale))
+		}
+
+		return bounds.Min.Y + int(math.Round(float64(y)*scale))
+	}
+
+	return image.Rect(fitX(rect.Min.X), fitY(rect.Min.Y), fitX(rect.Max.X), fitY(rect.Max.Y))
+}
+
+// phaseLayouts are checked in order, the first matching layout wins
+var phaseLayouts = []struct {
+	Phase  Phase
+	Layout rowLayout
+}{
+	{Phase: PhaseTrial, Layout: trialLayout},
+	{Phase: PhaseScoreboard, Layout: scoreboardLayout},
+	{Phase: PhaseLobby, Layout: lobbyLayout},
+}
+
+// detection is the result of the phase classification
+type detection struct {
+	Phase Phase
+	// Layout is fitted to the frame and the detected UI scale, it is zero if no rows were found
+	Layout rowLayout
+	// Spacing is the detected distance between the rows
+	Spacing int
+	// Scores holds the best periodicity score of every checked layout, it explains why a frame got its phase
+	Scores map[Phase]float64
+}
+
+// edgeThreshold is the minimal brightness difference of neighbour pixels that is counted as an edge
+const edgeThreshold = 40
+
+// minEntropy is the gray histogram entropy (in bits) below which the frame is considered blank,
+// e.g. loading screens, fades and static BRB cards
+const minEntropy = 3.5
+
+// maxMenuBrightness is the mean brightness of dark DBD menus
+const maxMenuBrightness = 0.35
+
+// classifyPhase labels the frame using cheap image heuristics and returns the layout of the detected rows,
+// text rows are detected by the periodicity of the horizontal edge density.
+// All layouts are searched at every UI scale starting from the default one, the row icons serve as anchors of the HUD.
+func classifyPhase(img image.Image) detection {
+	scores := make(map[Phase]float64, len(phaseLayouts))
+
+	for _, uiScale := range uiScales {
+		for _, candidate := range phaseLayouts {
+			layout := candidate.Layout.fit(img.Bounds(), uiScale)
+
+			spacing, score, ok := detectRows(img, layout)
+			if best, seen := scores[candidate.Phase]; !seen || score > best {
+				scores[candidate.Phase] = score
+			}
+
+			if ok {
+				if candidate.Layout.RowSpacing > 0 {
+					layout = candidate.Layout.fit(img.Bounds(), candidate.Layout.measureScale(img.Bounds(), spacing))
+				}
+
+				return detection{
+					Phase:   candidate.Phase,
+					Layout:  layout,
+					Spacing: spacing,
+					Scores:  scores,
+				}
+			}
+		}
+	}
+
+	entropy, brightness := grayHistogramStats(img)
+	if entropy >= minEntropy && brightness <= maxMenuBrightness {
+		return detection{Phase: PhaseMenu, Scores: scores}
+	}
+
+	return detection{Phase: PhaseUnknown, Scores: scores}
+}
+
+// detectRows checks whether the layout area contains evenly spaced text rows and returns their spacing and score
+func detectRows(img image.Image, layout rowLayout) (int, float64, bool) {
+	textProfile := edgeProfile(img, layout.Text)
+
+	spacing, score := bestPeriod(textProfile, layout.MinSpacing, layout.MaxSpacing)
+	if spacing == 0 {
+		return 0, 0, false
+	}
+
+	// row icons repeat with the same spacing and make the detection more reliable
+	if !layout.Icons.Empty() {
+		score += max(periodScore(edgeProfile(img, layout.Icons), spacing), 0) / 2
+	}
+
+	return spacing, score, score >= layout.Threshold
+}
+
+// edgeProfile returns the number of horizontal edges in every row of the area
+func edgeProfile(img image.Image, area image.Rectangle) []float64 {
+	area = area.Intersect(img.Bounds())
+	if area.Empty() {
+		return nil
+	}
+
+	result := make([]float64, area.Dy())
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		prev := grayAt(img, area.Min.X, y)
+
+		for x := area.Min.X + 1; x < area.Max.X; x++ {
+			cur := grayAt(img, x, y)
+			if math.Abs(cur-prev) > edgeThreshold {
+				result[y-area.Min.Y]++
+			}
+			prev = cur
+		}
+	}
+
+	return result
+}
+
+// bestPeriod finds the lag with the best periodicity score among local maximums of the autocorrelation
+func bestPeriod(profile []float64, minLag, maxLag int) (int, float64) {
+	autocorr := autocorrelation(profile, maxLag+1)
+	if autocorr == nil {
+		return 0, 0
+	}
+
+	bestLag := 0
+	bestScore := math.Inf(-1)
+
+	for lag := max(minLag, 2); lag < min(maxLag, len(autocorr)-1); lag++ {
+		if autocorr[lag] < autocorr[lag-1] || autocorr[lag] < autocorr[lag+1] {
+			continue
+		}
+
+		// evenly spaced rows correlate at the spacing, but not at the half of it
+		score := autocorr[lag] - autocorr[lag/2]
+		if score > bestScore {
+			bestLag = lag
+			bestScore = score
+		}
+	}
+
+	if bestLag == 0 {
+		return 0, 0
+	}
+
+	return bestLag, bestScore
+}
+
+func periodScore(profile []float64, lag int) float64 {
+	autocorr := autocorrelation(profile, lag+1)
+	if len(autocorr) <= lag {
+		return 0
+	}
+
+	return autocorr[lag] - autocorr[lag/2]
+}
+
+// autocorrelation returns the normalized autocorrelation of the mean-subtracted profile for lags below maxLag
+func autocorrelation(profile []float64, maxLag int) []float64 {
+	if len(profile) == 0 {
+		return nil
+	}
+
+	var mean float64
+	for _, v := range profile {
+		mean += v
+	}
+	mean /= float64(len(profile))
+
+	centered := make([]float64, len(profile))
+	var variance float64
+
+	for i, v := range profile {
+		centered[i] = v - mean
+		variance += centered[i] * centered[i]
+	}
+
+	if variance == 0 {
+		return nil
+	}
+
+	maxLag = min(maxLag, len(profile))
+	result := make([]float64, maxLag)
+
+	for lag := range maxLag {
+		var sum float64
+		for i := 0; i+lag < len(centered); i++ {
+			sum += centered[i] * centered[i+lag]
+		}
+		result[lag] = sum / variance
+	}
+
+	return result
+}
+
+// grayHistogramStats returns the entropy of the 32-bin gray histogram and the mean brightness in [0, 1]
+func grayHistogramStats(img image.Image) (float64, float64) {
+	const bins = 32
+	const step = 8
+
+	var histogram [bins]float64
+	var brightness float64
+	var count float64
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			gray := grayAt(img, x, y)
+			histogram[min(int(gray)*bins/256, bins-1)]++
+			brightness += gray / 255
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0, 0
+	}
+
+	var entropy float64
+	for _, v := range histogram {
+		if v == 0 {
+			continue
+		}
+
+		p := v / count
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy, brightness / count
+}
+
+func grayAt(img image.Image, x, y int) float64 {
+	r, g, b, _ := img.At(x, y).RGBA()
+	return (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+}
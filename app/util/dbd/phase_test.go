@@ -0,0 +1,124 @@
+package dbd
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xdraw "golang.org/x/image/draw"
+)
+
+func loadTestImage(t *testing.T, path string) image.Image {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	require.NoError(t, err)
+
+	return img
+}
+
+func TestClassifyPhase_Trial(t *testing.T) {
+	paths, err := filepath.Glob("test_dataset/*_1.*")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			img := loadTestImage(t, path)
+
+			detected := classifyPhase(img)
+			assert.Equal(t, PhaseTrial, detected.Phase)
+			assert.GreaterOrEqual(t, detected.Scores[PhaseTrial], trialLayout.Threshold)
+
+			// the same frame without the HUD is not a trial anymore
+			rgba := image.NewRGBA(img.Bounds())
+			draw.Draw(rgba, rgba.Bounds(), img, image.Point{}, draw.Src)
+			draw.Draw(rgba, trialLayout.Text.Union(trialLayout.Icons), image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+			assert.NotEqual(t, PhaseTrial, classifyPhase(rgba).Phase)
+		})
+	}
+}
+
+func TestClassifyPhase_Blank(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1920, 1080))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+	detected := classifyPhase(img)
+	assert.Equal(t, PhaseUnknown, detected.Phase)
+	assert.False(t, detected.Phase.InGame())
+
+	// every layout was checked and none of them has rows
+	require.Len(t, detected.Scores, len(phaseLayouts))
+	for _, candidate := range phaseLayouts {
+		assert.Less(t, detected.Scores[candidate.Phase], candidate.Layout.Threshold, candidate.Phase)
+	}
+}
+
+// resizeHUD shrinks the frame content towards its bottom left corner, like a smaller in-game UI scale does
+func resizeHUD(img image.Image, scale float64) image.Image {
+	bounds := img.Bounds()
+
+	result := image.NewRGBA(bounds)
+	draw.Draw(result, bounds, image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+	width := int(float64(bounds.Dx()) * scale)
+	height := int(float64(bounds.Dy()) * scale)
+	xdraw.CatmullRom.Scale(result, image.Rect(0, bounds.Dy()-height, width, bounds.Dy()), img, bounds, xdraw.Src, nil)
+
+	return result
+}
+
+func TestClassifyPhase_UIScale(t *testing.T) {
+	paths, err := filepath.Glob("test_dataset/*_1.*")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			img := loadTestImage(t, path)
+			original := classifyPhase(img)
+			require.Equal(t, PhaseTrial, original.Phase)
+
+			for _, scale := range []float64{0.9, 0.8, 0.7, 0.6} {
+				detected := classifyPhase(resizeHUD(img, scale))
+
+				assert.Equal(t, PhaseTrial, detected.Phase, "scale %v", scale)
+				assert.InDelta(t, original.Layout.Scale*scale, detected.Layout.Scale, 0.05, "scale %v", scale)
+			}
+		})
+	}
+}
+
+func TestClassifyPhase_AspectRatio(t *testing.T) {
+	img := loadTestImage(t, "test_dataset/bigwill82_1.png")
+	original := classifyPhase(img)
+	require.Equal(t, PhaseTrial, original.Phase)
+
+	// ultrawide frame, the HUD stays attached to the left edge
+	wide := image.NewRGBA(image.Rect(0, 0, 2560, 1080))
+	draw.Draw(wide, img.Bounds(), img, image.Point{}, draw.Src)
+
+	detected := classifyPhase(wide)
+	assert.Equal(t, PhaseTrial, detected.Phase)
+	assert.Equal(t, original.Layout.Names, detected.Layout.Names)
+
+	// 720p frame, the whole layout is scaled by the frame height
+	small := image.NewRGBA(image.Rect(0, 0, 1280, 720))
+	xdraw.CatmullRom.Scale(small, small.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+
+	detected = classifyPhase(small)
+	assert.Equal(t, PhaseTrial, detected.Phase)
+	assert.InDelta(t, original.Layout.Scale*2/3, detected.Layout.Scale, 0.05)
+}
@@ -0,0 +1,209 @@
+package dbd
+
+import (
+	"context"
+	"fmt"
+	"hyperfocus/app/util/imgproc"
+	"image"
+	"math"
+)
+
+// Role is the side the player plays on
+type Role string
+
+const (
+	RoleSurvivor Role = "survivor"
+	RoleKiller   Role = "killer"
+)
+
+// scoreboardPlayers is the number of rows of the end-game scoreboard: four survivors followed by the killer
+const scoreboardPlayers = 5
+
+// killerGap is the minimal extra distance, as a share of the row spacing, that sets the killer row apart from
+// the survivors above it. Disconnected survivors leave no empty rows, so the row index does not tell the role.
+const killerGap = 0.25
+
+// minRowDensity is the edge count of a line, relative to the densest one, below which the line has no text
+const minRowDensity = 0.25
+
+type Player struct {
+	Role       Role
+	Nickname   string
+	Confidence float64
+	// Slot is the index of the scoreboard row
+	Slot int
+	// Box is the bounding box of the nickname on the frame
+	Box image.Rectangle
+}
+
+// analyzeScoreboard recognizes every player row of the end-game scoreboard separately
+func (a *ImageAnalyzer) analyzeScoreboard(ctx context.Context, img image.Image, layout rowLayout, spacing int) ([]Player, error) {
+	rows := scoreboardRows(img, layout, spacing)
+
+	players := make([]Player, 0, len(rows))
+
+	for i, row := range rows {
+		// names are brought back to their size at 1080p and the default UI scale, like the HUD names
+		resize := 1 / layout.Scale
+		rowImage := imgproc.ForScoreboardRow(img, row.Box, resize)
+
+		res, err := a.ocrEngine.Recognize(ctx, rowImage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recognize row %d: %w", i, err)
+		}
+
+		// the row also contains scores and perks, the name is the most confident valid text
+		var best *Nickname
+		for _, nickname := range parseFragments(res) {
+			if !isValidUsername(nickname.Text) {
+				continue
+			}
+			if best == nil || nickname.Confidence > best.Confidence {
+				best = &nickname
+			}
+		}
+
+		if best == nil {
+			continue
+		}
+
+		box := row.Box
+		if !best.Box.Empty() {
+			box = cropToFrame(best.Box, row.Box, resize*imgproc.ScoreboardUpscale)
+		}
+
+		players = append(players, Player{
+			Role:       row.Role,
+			Nickname:   best.Text,
+			Confidence: best.Confidence,
+			Slot:       i,
+			Box:        box,
+		})
+	}
+
+	return players, nil
+}
+
+// scoreboardRow is a player row of the scoreboard, Role is empty if the row position does not tell it
+type scoreboardRow struct {
+	Box  image.Rectangle
+	Role Role
+}
+
+// scoreboardRows locates the player rows inside the scoreboard area using the detected row spacing.
+// The survivor rows follow each other at the spacing, the killer row is the first one after a wider gap.
+// Roles are left empty if there is no such gap.
+func scoreboardRows(img image.Image, layout rowLayout, spacing int) []scoreboardRow {
+	area := layout.Text.Intersect(img.Bounds())
+	if spacing <= 0 || area.Empty() {
+		return nil
+	}
+
+	centers := textBands(edgeProfile(img, area), spacing)
+
+	// rows are centered around their text
+	halfHeight := spacing * 2 / 5
+
+	result := make([]scoreboardRow, 0, scoreboardPlayers)
+	killer := -1
+
+	for i, center := range centers {
+		if len(result) == scoreboardPlayers {
+			break
+		}
+
+		row := image.Rect(area.Min.X, area.Min.Y+center-halfHeight, area.Max.X, area.Min.Y+center+halfHeight).Intersect(area)
+		if row.Dy() < halfHeight {
+			continue
+		}
+
+		if i > 0 && killer < 0 && float64(center-centers[i-1]) >= float64(spacing)*(1+killerGap) {
+			killer = len(result)
+		}
+
+		result = append(result, scoreboardRow{Box: row})
+
+		if killer >= 0 {
+			break
+		}
+	}
+
+	if killer < 0 {
+		return result
+	}
+
+	for i := range result {
+		result[i].Role = RoleSurvivor
+	}
+	result[killer].Role = RoleKiller
+
+	return result
+}
+
+// textBands returns the centers of the text rows of the edge profile: runs of dense lines,
+// the runs closer than a quarter of the spacing belong to the same row
+func textBands(profile []float64, spacing int) []int {
+	var maxLine float64
+	for _, v := range profile {
+		maxLine = max(maxLine, v)
+	}
+	if maxLine == 0 {
+		return nil
+	}
+
+	var result []int
+
+	start, end := -1, -1
+	for y, v := range profile {
+		if v < maxLine*minRowDensity {
+			continue
+		}
+
+		if start >= 0 && y-end > spacing/4 {
+			result = append(result, (start+end)/2)
+			start = -1
+		}
+		if start < 0 {
+			start = y
+		}
+		end = y
+	}
+
+	if start >= 0 {
+		result = append(result, (start+end)/2)
+	}
+
+	return result
+}
+
+// rowOffset returns the offset of the first row inside the area: the offset where the text density
+// summed over all rows is the highest, it points at the densest line of the row
+func rowOffset(img image.Image, area image.Rectangle, spacing int) int {
+	profile := edgeProfile(img, area)
+
+	bestOffset := 0
+	bestSum := -1.0
+
+	for offset := range spacing {
+		var sum float64
+		for y := offset; y < len(profile); y += spacing {
+			sum += profile[y]
+		}
+
+		if sum > bestSum {
+			bestOffset = offset
+			bestSum = sum
+		}
+	}
+
+	return bestOffset
+}
+
+// cropToFrame converts the box on the crop of the area that was resized by the factor to the frame coordinates
+func cropToFrame(box, area image.Rectangle, resize float64) image.Rectangle {
+	scale := func(v int) int {
+		return int(math.Round(float64(v) / resize))
+	}
+
+	return image.Rect(scale(box.Min.X), scale(box.Min.Y), scale(box.Max.X), scale(box.Max.Y)).Add(area.Min)
+}
@@ -0,0 +1,147 @@
+package dbd
+
+import (
+	"hyperfocus/app/util/imgproc"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drawScoreboard draws striped "text" lines in the middle of the player rows with the given centers
+func drawScoreboard(centers ...int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 1920, 1080))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+	for _, center := range centers {
+		for x := scoreboardLayout.Text.Min.X + 20; x < scoreboardLayout.Text.Min.X+400; x += 6 {
+			stripe := image.Rect(x, center-8, x+3, center+8)
+			draw.Draw(img, stripe, image.NewUniform(color.White), image.Point{}, draw.Src)
+		}
+	}
+
+	return img
+}
+
+func TestScoreboardRows(t *testing.T) {
+	const (
+		spacing  = 120
+		firstRow = 290
+	)
+
+	var centers []int
+	for i := range scoreboardPlayers {
+		centers = append(centers, firstRow+i*spacing)
+	}
+
+	rows := scoreboardRows(drawScoreboard(centers...), scoreboardLayout, spacing)
+	require.Len(t, rows, scoreboardPlayers)
+
+	for i, row := range rows {
+		center := centers[i]
+		assert.True(t, row.Box.Min.Y <= center-8 && row.Box.Max.Y >= center+8, "row %d %v does not contain its text", i, row.Box)
+	}
+
+	// without the gap the roles are unknown, the last row is not taken for the killer
+	for _, row := range rows {
+		assert.Empty(t, row.Role)
+	}
+}
+
+func TestScoreboardRows_KillerGap(t *testing.T) {
+	const (
+		spacing  = 110
+		firstRow = 260
+		gap      = 50
+	)
+
+	tests := []struct {
+		name      string
+		survivors int
+	}{
+		{name: "full lobby", survivors: 4},
+		{name: "disconnected survivor", survivors: 3},
+		{name: "single survivor", survivors: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var centers []int
+			for i := range tt.survivors {
+				centers = append(centers, firstRow+i*spacing)
+			}
+			killer := centers[len(centers)-1] + spacing + gap
+			centers = append(centers, killer)
+
+			rows := scoreboardRows(drawScoreboard(centers...), scoreboardLayout, spacing)
+			require.Len(t, rows, tt.survivors+1)
+
+			for i, row := range rows[:tt.survivors] {
+				assert.Equal(t, RoleSurvivor, row.Role, i)
+			}
+
+			last := rows[tt.survivors]
+			assert.Equal(t, RoleKiller, last.Role)
+			assert.True(t, last.Box.Min.Y <= killer-8 && last.Box.Max.Y >= killer+8, "killer row %v does not contain its text", last.Box)
+		})
+	}
+}
+
+func TestScoreboardRows_NoSpacing(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1920, 1080))
+
+	assert.Empty(t, scoreboardRows(img, scoreboardLayout, 0))
+}
+
+func TestNewScoreboardResult(t *testing.T) {
+	result := newScoreboardResult([]Player{
+		{Role: RoleSurvivor, Nickname: "first", Confidence: 0.9},
+		{Role: RoleSurvivor, Nickname: "second", Confidence: 0.8},
+		{Role: RoleKiller, Nickname: "killer", Confidence: 0.95},
+	})
+
+	assert.Equal(t, PhaseScoreboard, result.Phase)
+	assert.Equal(t, []string{"first", "second", "killer"}, result.Usernames)
+	require.Len(t, result.Nicknames, 3)
+	assert.Equal(t, RoleSurvivor, result.Nicknames[0].Role)
+	assert.Equal(t, RoleKiller, result.Nicknames[2].Role)
+	assert.Equal(t, "killer", result.Killer)
+
+	// without the scoreboard gap the killer is unknown
+	assert.Empty(t, newScoreboardResult([]Player{{Nickname: "first"}}).Killer)
+}
+
+func TestScoreboardRow_BoxToFrame(t *testing.T) {
+	layout := scoreboardLayout.fit(image.Rect(0, 0, 1920, 1080), 0.8)
+	text := image.Rect(layout.Text.Min.X+40, 330, layout.Text.Min.X+240, 350)
+	row := image.Rect(layout.Text.Min.X, 300, layout.Text.Max.X, 380)
+
+	img := image.NewRGBA(image.Rect(0, 0, 1920, 1080))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Gray{Y: 30}), image.Point{}, draw.Src)
+	draw.Draw(img, text, image.White, image.Point{}, draw.Src)
+
+	resize := 1 / layout.Scale
+	rowImage := imgproc.ForScoreboardRow(img, row, resize)
+
+	assert.InDelta(t, float64(row.Dx())*resize*imgproc.ScoreboardUpscale, float64(rowImage.Bounds().Dx()), 1)
+
+	// the box of the dark text on the processed row, as the OCR engine reports it
+	var box image.Rectangle
+	for y := range rowImage.Rect.Dy() {
+		for x := range rowImage.Rect.Dx() {
+			if rowImage.GrayAt(x, y).Y < 128 {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	require.False(t, box.Empty())
+
+	frameBox := cropToFrame(box, row, resize*imgproc.ScoreboardUpscale)
+	assert.InDelta(t, text.Min.X, frameBox.Min.X, 2)
+	assert.InDelta(t, text.Min.Y, frameBox.Min.Y, 2)
+	assert.InDelta(t, text.Max.X, frameBox.Max.X, 2)
+	assert.InDelta(t, text.Max.Y, frameBox.Max.Y, 2)
+}
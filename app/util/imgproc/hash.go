@@ -0,0 +1,77 @@
+package imgproc
+
+import (
+	"image"
+	"math/bits"
+)
+
+// hashWidth and hashHeight are the size of the difference hash grid, name lists are tall,
+// so the grid has more rows to notice a change of a single name
+const (
+	hashWidth  = 16
+	hashHeight = 32
+)
+
+// hashMinDifference is the brightness difference of neighbour cells that sets the bit,
+// flat areas would produce random bits out of compression noise otherwise
+const hashMinDifference = 8
+
+// DHash is a difference hash of the image: every bit tells whether a cell of the downscaled
+// image is brighter than its right neighbour. Similar images have hashes with a small Hamming distance.
+type DHash [hashWidth * hashHeight / 64]uint64
+
+func NewDHash(img *image.Gray) DHash {
+	cells := boxDownscale(img, hashWidth+1, hashHeight)
+
+	var result DHash
+
+	for y := range hashHeight {
+		row := cells[y*(hashWidth+1):]
+
+		for x := range hashWidth {
+			if row[x] > row[x+1]+hashMinDifference {
+				bit := y*hashWidth + x
+				result[bit/64] |= 1 << (bit % 64)
+			}
+		}
+	}
+
+	return result
+}
+
+// boxDownscale returns the mean brightness of every cell of the width x height grid,
+// averaging whole cells makes the hash robust to compression noise and small shifts
+func boxDownscale(img *image.Gray, width, height int) []float64 {
+	bounds := img.Bounds()
+	sums := make([]float64, width*height)
+	counts := make([]float64, width*height)
+
+	for y := range bounds.Dy() {
+		row := img.Pix[y*img.Stride:]
+		cellY := y * height / bounds.Dy()
+
+		for x := range bounds.Dx() {
+			cell := cellY*width + x*width/bounds.Dx()
+			sums[cell] += float64(row[x])
+			counts[cell]++
+		}
+	}
+
+	for i := range sums {
+		if counts[i] > 0 {
+			sums[i] /= counts[i]
+		}
+	}
+
+	return sums
+}
+
+// Distance returns the number of differing bits
+func (h DHash) Distance(other DHash) int {
+	var result int
+	for i := range h {
+		result += bits.OnesCount64(h[i] ^ other[i])
+	}
+
+	return result
+}
@@ -0,0 +1,48 @@
+package imgproc
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hudGray is the image the analyzer hashes
+func hudGray(img image.Image) *image.Gray {
+	gray := CropGray(img, usernamesArea)
+	AutoLevel(gray)
+
+	return gray
+}
+
+func TestDHash(t *testing.T) {
+	paths, err := filepath.Glob("../dbd/test_dataset/*_1.*")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	var hashes []DHash
+
+	for _, path := range paths {
+		img := loadTestImage(t, path)
+		hash := NewDHash(hudGray(img))
+
+		// the same frame after another round of lossy compression, like the next frame of a still HUD
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}))
+		recompressed, err := jpeg.Decode(&buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, hash.Distance(hash))
+		assert.LessOrEqual(t, hash.Distance(NewDHash(hudGray(recompressed))), 8, path)
+
+		for _, other := range hashes {
+			assert.Greater(t, hash.Distance(other), 40, path)
+		}
+
+		hashes = append(hashes, hash)
+	}
+}
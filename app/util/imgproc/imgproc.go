@@ -0,0 +1,200 @@
+// Package imgproc implements the image preprocessing for OCR in pure Go,
+// the operations follow their ImageMagick counterparts the pipelines were tuned with.
+package imgproc
+
+import (
+	"image"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// CropGray crops the area and converts it to grayscale using Rec. 709 luma (magick -colorspace Gray)
+func CropGray(img image.Image, area image.Rectangle) *image.Gray {
+	area = area.Intersect(img.Bounds())
+	result := image.NewGray(image.Rect(0, 0, area.Dx(), area.Dy()))
+
+	switch src := img.(type) {
+	case *image.RGBA:
+		cropGrayPix(result, src.Pix, src.Stride, src.PixOffset(area.Min.X, area.Min.Y))
+	case *image.NRGBA:
+		// the alpha channel is ignored like with magick -alpha off
+		cropGrayPix(result, src.Pix, src.Stride, src.PixOffset(area.Min.X, area.Min.Y))
+	default:
+		for y := range area.Dy() {
+			row := result.Pix[y*result.Stride:]
+
+			for x := range area.Dx() {
+				r, g, b, _ := img.At(area.Min.X+x, area.Min.Y+y).RGBA()
+				row[x] = luma(r>>8, g>>8, b>>8)
+			}
+		}
+	}
+
+	return result
+}
+
+// cropGrayPix converts 4 bytes per pixel RGB data starting at offset into dst
+func cropGrayPix(dst *image.Gray, pix []uint8, stride, offset int) {
+	for y := range dst.Rect.Dy() {
+		src := pix[offset+y*stride:]
+		row := dst.Pix[y*dst.Stride:]
+
+		for x := range dst.Rect.Dx() {
+			row[x] = luma(uint32(src[x*4]), uint32(src[x*4+1]), uint32(src[x*4+2]))
+		}
+	}
+}
+
+func luma(r, g, b uint32) uint8 {
+	return uint8((2126*r + 7152*g + 722*b + 5000) / 10000)
+}
+
+// Resize scales the image by the factor (magick -resize N%)
+func Resize(img *image.Gray, factor float64) *image.Gray {
+	if factor == 1 || factor <= 0 {
+		return img
+	}
+
+	width := max(int(math.Round(float64(img.Bounds().Dx())*factor)), 1)
+	height := max(int(math.Round(float64(img.Bounds().Dy())*factor)), 1)
+
+	result := image.NewGray(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(result, result.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+
+	return result
+}
+
+// AutoLevel stretches the pixel values to the full range in place (magick -auto-level)
+func AutoLevel(img *image.Gray) {
+	if len(img.Pix) == 0 {
+		return
+	}
+
+	lo, hi := img.Pix[0], img.Pix[0]
+	for _, v := range img.Pix {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	if lo == hi {
+		return
+	}
+
+	var lut [256]uint8
+	for v := int(lo); v <= int(hi); v++ {
+		lut[v] = uint8((v - int(lo)) * 255 / (int(hi) - int(lo)))
+	}
+
+	for i, v := range img.Pix {
+		img.Pix[i] = lut[v]
+	}
+}
+
+// LocalAdaptiveThreshold returns the image where every pixel that is brighter than the mean of the
+// width x height window around it plus the offset becomes white (magick -lat WxH+offset%).
+// offset is a fraction of the full range, the window is clipped at the image edges.
+func LocalAdaptiveThreshold(img *image.Gray, width, height int, offset float64) *image.Gray {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	// summed area table with an extra zero row and column
+	integral := make([]int64, (w+1)*(h+1))
+	for y := range h {
+		var rowSum int64
+		for x := range w {
+			rowSum += int64(img.Pix[y*img.Stride+x])
+			integral[(y+1)*(w+1)+x+1] = integral[y*(w+1)+x+1] + rowSum
+		}
+	}
+
+	bias := offset * 255
+	result := image.NewGray(image.Rect(0, 0, w, h))
+
+	for y := range h {
+		y0 := max(y-height/2, 0)
+		y1 := min(y-height/2+height, h)
+
+		for x := range w {
+			x0 := max(x-width/2, 0)
+			x1 := min(x-width/2+width, w)
+
+			sum := integral[y1*(w+1)+x1] - integral[y0*(w+1)+x1] - integral[y1*(w+1)+x0] + integral[y0*(w+1)+x0]
+			mean := float64(sum) / float64((x1-x0)*(y1-y0))
+
+			if float64(img.Pix[y*img.Stride+x]) > mean+bias {
+				result.Pix[y*result.Stride+x] = 255
+			}
+		}
+	}
+
+	return result
+}
+
+// Threshold returns the image where pixels brighter than the level become white (magick -threshold N%),
+// level is a fraction of the full range
+func Threshold(img *image.Gray, level float64) *image.Gray {
+	bounds := img.Bounds()
+	result := image.NewGray(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	limit := level * 255
+
+	for y := range bounds.Dy() {
+		for x := range bounds.Dx() {
+			if float64(img.Pix[y*img.Stride+x]) > limit {
+				result.Pix[y*result.Stride+x] = 255
+			}
+		}
+	}
+
+	return result
+}
+
+// DarkenMasked keeps the darker pixel of dst and src in dst where the mask is black, the white pixels of the mask
+// are protected. All images have the same size (magick -compose darken -composite with a mask as the third image).
+func DarkenMasked(dst, src, mask *image.Gray) {
+	for i := range dst.Pix {
+		if mask.Pix[i] != 0 {
+			continue
+		}
+
+		dst.Pix[i] = min(dst.Pix[i], src.Pix[i])
+	}
+}
+
+// Negate inverts the image in place (magick -negate)
+func Negate(img *image.Gray) {
+	for i, v := range img.Pix {
+		img.Pix[i] = 255 - v
+	}
+}
+
+// ForUsernames prepares the HUD names crop for OCR: dark text on white background
+func ForUsernames(img image.Image, area image.Rectangle, resize float64) *image.Gray {
+	gray := Resize(CropGray(img, area), resize)
+	AutoLevel(gray)
+
+	// the same image list as "( +clone -lat 8x8+5% ) ( +clone -threshold 60% ) -compose darken -composite":
+	// the second clone is taken from the adaptive threshold, and the third image of the list is the composition mask.
+	// The pixels outside the mask are darkened by the black adaptive threshold, so everything but the text
+	// that stands out of its neighbourhood becomes the background
+	adaptive := LocalAdaptiveThreshold(gray, 8, 8, 0.05)
+	DarkenMasked(gray, adaptive, Threshold(adaptive, 0.6))
+	Negate(gray)
+
+	return gray
+}
+
+// ScoreboardUpscale is the factor ForScoreboardRow upscales the row by on top of the requested resize
+const ScoreboardUpscale = 2
+
+// ForScoreboardRow prepares a single player row of the end-game scoreboard,
+// the text there is large enough to be upscaled and binarized with a global threshold
+func ForScoreboardRow(img image.Image, area image.Rectangle, resize float64) *image.Gray {
+	gray := Resize(CropGray(img, area), ScoreboardUpscale*resize)
+	AutoLevel(gray)
+
+	result := Threshold(gray, 0.55)
+	Negate(result)
+
+	return result
+}
@@ -0,0 +1,213 @@
+package imgproc
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/bmp"
+)
+
+// usernamesArea is the HUD names area of test_dataset frames
+var usernamesArea = image.Rect(145, 420, 378, 835)
+
+// updateGolden regenerates the golden images with ImageMagick:
+// go test ./app/util/imgproc -run TestForUsernames_MatchesMagick -update
+var updateGolden = flag.Bool("update", false, "regenerate the ImageMagick golden images in testdata")
+
+func loadTestImage(tb testing.TB, path string) image.Image {
+	tb.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(tb, err)
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	require.NoError(tb, err)
+
+	return img
+}
+
+// magickForUsernames runs the ImageMagick command the pure Go pipeline replaces
+func magickForUsernames(tb testing.TB, img image.Image, area image.Rectangle) image.Image {
+	tb.Helper()
+
+	_, err := exec.LookPath("magick")
+	require.NoError(tb, err, "magick is needed to regenerate the golden images")
+
+	var input bytes.Buffer
+	require.NoError(tb, bmp.Encode(&input, img))
+
+	cmd := exec.Command("magick",
+		"bmp:-",
+		"-crop", fmt.Sprintf("%dx%d+%d+%d", area.Dx(), area.Dy(), area.Min.X, area.Min.Y),
+		"+repage",
+		"-colorspace", "Gray",
+		"-auto-level",
+		"(", "+clone", "-lat", "8x8+5%", ")",
+		"(", "+clone", "-threshold", "60%", ")",
+		"-compose", "darken", "-composite",
+		"-negate",
+		"-alpha", "off",
+		"bmp:-",
+	)
+	cmd.Stdin = &input
+
+	output, err := cmd.Output()
+	require.NoError(tb, err)
+
+	result, err := bmp.Decode(bytes.NewReader(output))
+	require.NoError(tb, err)
+
+	return result
+}
+
+// goldenPath returns the ImageMagick output stored for the test_dataset frame
+func goldenPath(path string) string {
+	return filepath.Join("testdata", strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))+"_usernames.png")
+}
+
+// loadGolden returns the ImageMagick output for the frame, regenerating it if -update is set
+func loadGolden(t *testing.T, path string, img image.Image) image.Image {
+	t.Helper()
+
+	golden := goldenPath(path)
+
+	if *updateGolden {
+		result := magickForUsernames(t, img, usernamesArea)
+
+		var output bytes.Buffer
+		require.NoError(t, png.Encode(&output, result))
+		require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+		require.NoError(t, os.WriteFile(golden, output.Bytes(), 0o644))
+
+		return result
+	}
+
+	_, err := os.Stat(golden)
+	require.NoError(t, err, "golden image is missing, generate it with ImageMagick by running the test with -update")
+
+	return loadTestImage(t, golden)
+}
+
+func TestForUsernames_MatchesMagick(t *testing.T) {
+	paths, err := filepath.Glob("../dbd/test_dataset/*_1.*")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			img := loadTestImage(t, path)
+
+			expected := loadGolden(t, path, img)
+			actual := ForUsernames(img, usernamesArea, 1)
+			require.Equal(t, expected.Bounds().Size(), actual.Bounds().Size())
+
+			var mismatched int
+			for y := range actual.Rect.Dy() {
+				for x := range actual.Rect.Dx() {
+					e := color.GrayModel.Convert(expected.At(expected.Bounds().Min.X+x, expected.Bounds().Min.Y+y)).(color.Gray) //nolint:forcetypeassert
+					if diff := int(e.Y) - int(actual.GrayAt(x, y).Y); diff > 8 || diff < -8 {
+						mismatched++
+					}
+				}
+			}
+
+			// rounding of the luma and the adaptive threshold window at the edges differ slightly
+			assert.Less(t, float64(mismatched)/float64(len(actual.Pix)), 0.02)
+		})
+	}
+}
+
+func TestAutoLevel(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 3, 1))
+	img.Pix = []uint8{50, 100, 150}
+
+	AutoLevel(img)
+
+	assert.Equal(t, []uint8{0, 127, 255}, img.Pix)
+}
+
+func TestLocalAdaptiveThreshold(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		img.Pix[i] = 100
+	}
+	// a bright dot stands out of its neighbourhood, the flat background does not
+	img.SetGray(8, 8, color.Gray{Y: 200})
+
+	result := LocalAdaptiveThreshold(img, 8, 8, 0.05)
+
+	assert.Equal(t, uint8(255), result.GrayAt(8, 8).Y)
+	assert.Equal(t, uint8(0), result.GrayAt(7, 8).Y)
+	assert.Equal(t, uint8(0), result.GrayAt(0, 0).Y)
+}
+
+func TestDarkenMasked(t *testing.T) {
+	dst := image.NewGray(image.Rect(0, 0, 3, 1))
+	dst.Pix = []uint8{200, 200, 200}
+	src := image.NewGray(image.Rect(0, 0, 3, 1))
+	src.Pix = []uint8{0, 255, 100}
+	mask := image.NewGray(image.Rect(0, 0, 3, 1))
+	mask.Pix = []uint8{0, 255, 0}
+
+	DarkenMasked(dst, src, mask)
+
+	// the white pixel of the mask is protected
+	assert.Equal(t, []uint8{0, 200, 100}, dst.Pix)
+}
+
+func TestForUsernames(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	for y := range 50 {
+		for x := range 100 {
+			img.Set(x, y, color.RGBA{R: 20, G: 20, B: 20, A: 255})
+		}
+	}
+	// white "text" stroke on the dark HUD background
+	for x := 30; x < 60; x++ {
+		img.Set(x, 25, color.White)
+	}
+
+	result := ForUsernames(img, image.Rect(10, 10, 90, 40), 2)
+
+	assert.Equal(t, image.Pt(160, 60), result.Bounds().Size())
+	// OCR gets dark text on a white background
+	assert.Greater(t, result.GrayAt(0, 0).Y, uint8(200))
+	assert.Less(t, result.GrayAt(80, 30).Y, uint8(50))
+	// the dim pixels around the stroke are not above their neighbourhood and are darkened into the background
+	assert.Equal(t, uint8(255), result.GrayAt(80, 26).Y)
+}
+
+func BenchmarkForUsernames(b *testing.B) {
+	img := loadTestImage(b, "../dbd/test_dataset/bigwill82_1.png")
+
+	b.ResetTimer()
+	for range b.N {
+		ForUsernames(img, usernamesArea, 1)
+	}
+}
+
+func BenchmarkForUsernames_Magick(b *testing.B) {
+	if _, err := exec.LookPath("magick"); err != nil {
+		b.Skip("magick is not installed")
+	}
+
+	img := loadTestImage(b, "../dbd/test_dataset/bigwill82_1.png")
+
+	b.ResetTimer()
+	for range b.N {
+		magickForUsernames(b, img, usernamesArea)
+	}
+}
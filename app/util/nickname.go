@@ -0,0 +1,75 @@
+package util
+
+import "strings"
+
+// ocrConfusables maps the characters that OCR mistakes for each other to a single one,
+// lowercase "i" is included since uppercase "I" is lowercased before the mapping
+var ocrConfusables = strings.NewReplacer(
+	"rn", "m",
+	"i", "l",
+	"1", "l",
+	"|", "l",
+	"0", "o",
+	"5", "s",
+)
+
+// NormalizeNickname returns the form of the nickname that is used for matching:
+// lowercased, trimmed, with inner whitespace collapsed and OCR confusables (l/I/1, O/0, rn/m, S/5) unified.
+// normalize_nickname in the database mirrors it and has to be kept in sync.
+func NormalizeNickname(nickname string) string {
+	return ocrConfusables.Replace(strings.ToLower(strings.Join(strings.Fields(nickname), " ")))
+}
+
+// ConfusableCost is the cost of substituting characters that look alike but are not unified by NormalizeNickname
+const ConfusableCost = 0.5
+
+var confusablePairs = map[[2]rune]struct{}{}
+
+func init() {
+	pairs := []string{"b8", "g9", "q9", "gq", "z2", "ce", "e3", "a4", "t7", "uv", "vy", "hn", "mn", "-_", ".,"}
+
+	for _, pair := range pairs {
+		runes := []rune(pair)
+		confusablePairs[[2]rune{runes[0], runes[1]}] = struct{}{}
+		confusablePairs[[2]rune{runes[1], runes[0]}] = struct{}{}
+	}
+}
+
+// NicknameDistance returns the edit distance between the normalized nicknames
+// where substitutions of look-alike characters cost ConfusableCost instead of 1
+func NicknameDistance(a, b string) float64 {
+	s1 := []rune(NormalizeNickname(a))
+	s2 := []rune(NormalizeNickname(b))
+
+	prev := make([]float64, len(s1)+1)
+	cur := make([]float64, len(s1)+1)
+
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+
+	for i := 1; i <= len(s2); i++ {
+		cur[0] = float64(i)
+
+		for j := 1; j <= len(s1); j++ {
+			cost := substitutionCost(s1[j-1], s2[i-1])
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(s1)]
+}
+
+func substitutionCost(a, b rune) float64 {
+	if a == b {
+		return 0
+	}
+
+	if _, ok := confusablePairs[[2]rune{a, b}]; ok {
+		return ConfusableCost
+	}
+
+	return 1
+}
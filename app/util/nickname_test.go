@@ -0,0 +1,35 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeNickname(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"k0per1s", "kOper1s"},
+		{"  Claudette   Morel_01 ", "claudette morel_Ol"},
+		{"Iris", "lris"},
+		{"Demi", "Dernl"},
+		{"5unnie", "Sunnie"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, NormalizeNickname(tt.a), NormalizeNickname(tt.b), "%s vs %s", tt.a, tt.b)
+	}
+
+	assert.Equal(t, "claudette morel_ol", NormalizeNickname("  Claudette   Morel_01 "))
+	assert.Equal(t, NormalizeNickname("Demi"), NormalizeNickname(NormalizeNickname("Demi")))
+}
+
+func TestNicknameDistance(t *testing.T) {
+	assert.Zero(t, NicknameDistance("k0per1s", "kOperIs"))
+	assert.InDelta(t, ConfusableCost, NicknameDistance("Bigwill82", "Bigwill8Z"), 1e-9)
+	assert.InDelta(t, 1, NicknameDistance("Clappnz", "Clappz"), 1e-9)
+	assert.InDelta(t, 1+ConfusableCost, NicknameDistance("Grim_zy", "Grim-z"), 1e-9)
+	assert.InDelta(t, 3, NicknameDistance("abc", ""), 1e-9)
+	assert.Equal(t, NicknameDistance("Katt", "Kate"), NicknameDistance("Kate", "Katt"))
+}
@@ -1,13 +1,102 @@
 package telemetry
 
 import (
+	"context"
 	"hyperfocus/app/config"
+	"time"
 
+	"github.com/samber/oops"
+	"go.opentelemetry.io/otel/attribute"
 	otelmetric "go.opentelemetry.io/otel/metric"
 )
 
-type Metrics struct{}
+type Metrics struct {
+	ocrRequestDuration otelmetric.Float64Histogram
+	ocrBatchSize       otelmetric.Int64Histogram
+	ocrCacheLookups    otelmetric.Int64Counter
+	framePhases        otelmetric.Int64Counter
+	framePhaseScore    otelmetric.Float64Histogram
+}
+
+func NewMetrics(_ *config.Config, meter otelmetric.Meter) (*Metrics, error) {
+	ocrRequestDuration, err := meter.Float64Histogram("ocr.request.duration",
+		otelmetric.WithDescription("Duration of OCR requests"),
+		otelmetric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, oops.Errorf("failed to create ocr.request.duration histogram: %w", err)
+	}
+
+	ocrBatchSize, err := meter.Int64Histogram("ocr.batch.size",
+		otelmetric.WithDescription("Number of images sent in one OCR request"),
+		otelmetric.WithUnit("{image}"),
+		otelmetric.WithExplicitBucketBoundaries(1, 2, 4, 8, 16, 32, 64),
+	)
+	if err != nil {
+		return nil, oops.Errorf("failed to create ocr.batch.size histogram: %w", err)
+	}
+
+	ocrCacheLookups, err := meter.Int64Counter("ocr.cache.lookups",
+		otelmetric.WithDescription("Lookups of the previous OCR result of the unchanged HUD, the hit rate is the share of hit=true"),
+		otelmetric.WithUnit("{lookup}"),
+	)
+	if err != nil {
+		return nil, oops.Errorf("failed to create ocr.cache.lookups counter: %w", err)
+	}
+
+	framePhases, err := meter.Int64Counter("frame.phases",
+		otelmetric.WithDescription("Analyzed frames by the detected game phase"),
+		otelmetric.WithUnit("{frame}"),
+	)
+	if err != nil {
+		return nil, oops.Errorf("failed to create frame.phases counter: %w", err)
+	}
+
+	framePhaseScore, err := meter.Float64Histogram("frame.phase.score",
+		otelmetric.WithDescription("Best row periodicity score of every phase layout checked on the frame"),
+		otelmetric.WithExplicitBucketBoundaries(0, 0.1, 0.2, 0.3, 0.4, 0.45, 0.5, 0.6, 0.7, 0.8, 0.9, 1),
+	)
+	if err != nil {
+		return nil, oops.Errorf("failed to create frame.phase.score histogram: %w", err)
+	}
+
+	return &Metrics{
+		ocrRequestDuration: ocrRequestDuration,
+		ocrBatchSize:       ocrBatchSize,
+		ocrCacheLookups:    ocrCacheLookups,
+		framePhases:        framePhases,
+		framePhaseScore:    framePhaseScore,
+	}, nil
+}
+
+// RecordOCRRequest records a single request to the OCR engine that recognized batchSize images
+func (m *Metrics) RecordOCRRequest(ctx context.Context, engine string, batchSize int, duration time.Duration, err error) {
+	attrs := otelmetric.WithAttributes(
+		attribute.String("engine", engine),
+		attribute.Bool("error", err != nil),
+	)
+
+	m.ocrRequestDuration.Record(ctx, duration.Seconds(), attrs)
+	m.ocrBatchSize.Record(ctx, int64(batchSize), attrs)
+}
+
+// RecordOCRCacheLookup records whether the OCR result of the previous frame was reused
+func (m *Metrics) RecordOCRCacheLookup(ctx context.Context, hit bool) {
+	m.ocrCacheLookups.Add(ctx, 1, otelmetric.WithAttributes(
+		attribute.Bool("hit", hit),
+	))
+}
+
+// RecordFramePhase records the phase detected on the frame and the scores of the layouts that were checked
+func (m *Metrics) RecordFramePhase(ctx context.Context, phase string, scores map[string]float64) {
+	m.framePhases.Add(ctx, 1, otelmetric.WithAttributes(
+		attribute.String("phase", phase),
+	))
 
-func NewMetrics(_ *config.Config, _ otelmetric.Meter) (*Metrics, error) {
-	return &Metrics{}, nil
+	for layout, score := range scores {
+		m.framePhaseScore.Record(ctx, score, otelmetric.WithAttributes(
+			attribute.String("layout", layout),
+			attribute.Bool("detected", layout == phase),
+		))
+	}
 }
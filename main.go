@@ -19,6 +19,7 @@ func main() {
 
 	rootCmd := &cobra.Command{Use: "hyperfocus"}
 	rootCmd.AddCommand(cmd.Server)
+	rootCmd.AddCommand(cmd.Token)
 	rootCmd.AddCommand(extension.NewVersionCobraCmd())
 
 	if err := rootCmd.Execute(); err != nil {